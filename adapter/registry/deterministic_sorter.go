@@ -0,0 +1,90 @@
+package registry
+
+import (
+	"context"
+	"sort"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+// deterministicSorter feeds the wrapped sorter its input in ID order, so even a
+// sorter built on an unstable sort returns the same order for the same catalog
+// regardless of how the caller happened to order it. Sorters that break ties on
+// product ID get their input as it is.
+type deterministicSorter struct {
+	sorter service.Sorter
+
+	breaksTiesByID bool
+}
+
+func newDeterministicSorter(sorter service.Sorter) service.Sorter {
+	if _, ok := sorter.(*deterministicSorter); ok {
+		return sorter
+	}
+
+	tieBreaker, ok := sorter.(service.IDTieBreaker)
+	return &deterministicSorter{sorter: sorter, breaksTiesByID: ok && tieBreaker.BreaksTiesByID()}
+}
+
+func (s *deterministicSorter) Sort(products model.ProductList) model.ProductList {
+	return s.sorter.Sort(s.input(products))
+}
+
+func (s *deterministicSorter) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	return service.AsSorterV2(s.sorter).SortContext(ctx, s.input(products))
+}
+
+// SortTopK uses the wrapped sorter's partial sort when it has one, and falls
+// back to a full sort otherwise.
+func (s *deterministicSorter) SortTopK(products model.ProductList, k int) model.ProductList {
+	topK, ok := s.sorter.(service.TopKSorter)
+	if !ok {
+		sorted := s.Sort(products)
+		if k < len(sorted) {
+			sorted = sorted[:max(k, 0)]
+		}
+		return sorted
+	}
+
+	return topK.SortTopK(s.input(products), k)
+}
+
+func (s *deterministicSorter) Explain(products model.ProductList) []service.RankExplanation {
+	return service.Explain(s.sorter, s.input(products))
+}
+
+// BreaksTiesByID reports true: whatever the wrapped sorter does, its output
+// does not depend on the order of the input.
+func (s *deterministicSorter) BreaksTiesByID() bool {
+	return true
+}
+
+// input returns products in the order the wrapped sorter is given them.
+func (s *deterministicSorter) input(products model.ProductList) model.ProductList {
+	if s.breaksTiesByID {
+		return products
+	}
+	return orderByID(products)
+}
+
+func orderByID(products model.ProductList) model.ProductList {
+	lessByID := func(i, j int) bool { return products[i].ID < products[j].ID }
+	if sort.SliceIsSorted(products, lessByID) {
+		return products
+	}
+
+	ordered := make(model.ProductList, len(products))
+	copy(ordered, products)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+
+	return ordered
+}
+
+func (s *deterministicSorter) Name() string {
+	return s.sorter.Name()
+}
+
+func (s *deterministicSorter) Unwrap() service.Sorter {
+	return s.sorter
+}
@@ -1,58 +1,364 @@
 package registry
 
 import (
+	"cmp"
+	"fmt"
+	"slices"
 	"sync"
 
 	"assessment/domain/service"
 )
 
+// SorterRegistry keys sorters by their ID. A sorter can also be looked up by
+// its Name and any aliases it declares.
 type SorterRegistry struct {
 	sorters map[string]service.Sorter
-	mutex   sync.RWMutex
+	aliases map[string]string
+
+	// registered holds the IDs in registration order, and order the IDs or
+	// aliases set with SetOrder.
+	registered []string
+	order      []string
+
+	validators []service.SorterValidator
+
+	observers      []subscription
+	nextObserverID int
+
+	// pending holds the events of changes not yet delivered to observers, in
+	// the order the changes were made. While delivering is set, one goroutine
+	// drains it outside mutex.
+	pending    []pendingEvent
+	delivering bool
+
+	mutex sync.RWMutex
+}
+
+type subscription struct {
+	id       int
+	observer service.SorterObserver
+}
+
+type pendingEvent struct {
+	event     service.SorterEvent
+	observers []subscription
 }
 
+// NewSorterRegistry returns a registry that rejects sorters with an empty
+// name.
 func NewSorterRegistry() *SorterRegistry {
 	return &SorterRegistry{
-		sorters: make(map[string]service.Sorter),
+		sorters:    make(map[string]service.Sorter),
+		aliases:    make(map[string]string),
+		validators: []service.SorterValidator{service.ValidateSorterName},
 	}
 }
 
-func (r *SorterRegistry) RegisterSorter(sorter service.Sorter) {
+// AddValidator adds a check that sorters must pass to be registered.
+func (r *SorterRegistry) AddValidator(validator service.SorterValidator) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	r.sorters[sorter.Name()] = sorter
+	r.validators = append(r.validators, validator)
 }
 
+// RegisterSorter registers sorter with ConflictReplace. Sorters that fail
+// validation are not registered; use Register to see why.
+func (r *SorterRegistry) RegisterSorter(sorter service.Sorter) {
+	_ = r.Register(sorter, service.ConflictReplace)
+}
+
+// Register validates sorter and registers it, resolving any sorter whose ID
+// or alias it would take over according to policy. Sorters the policy lets it
+// take over are removed, and it takes the listing place of the first of them.
+func (r *SorterRegistry) Register(sorter service.Sorter, policy service.ConflictPolicy) error {
+	r.mutex.Lock()
+
+	for _, validate := range r.validators {
+		if err := validate(sorter); err != nil {
+			r.mutex.Unlock()
+			return fmt.Errorf("%w: %w", service.ErrInvalidSorter, err)
+		}
+	}
+
+	id := service.SorterID(sorter)
+	aliases := service.SorterAliases(sorter)
+
+	conflicts := r.conflicts(id, aliases)
+	for _, existing := range conflicts {
+		if err := service.CheckConflict(r.sorters[existing], sorter, policy); err != nil {
+			r.mutex.Unlock()
+			return err
+		}
+	}
+
+	eventType := service.SorterRegistered
+	if len(conflicts) > 0 {
+		eventType = service.SorterReplaced
+	}
+
+	// Sorters registered under another ID are unregistered, and the new
+	// sorter is listed in place of the earliest registered of them.
+	var events []service.SorterEvent
+	slot := len(r.registered)
+	for _, existing := range conflicts {
+		if existing == id {
+			continue
+		}
+		slot = min(slot, slices.Index(r.registered, existing))
+		events = append(events, service.NewSorterEvent(service.SorterUnregistered, r.sorters[existing]))
+	}
+	for _, existing := range conflicts {
+		if existing != id {
+			r.remove(existing)
+		}
+	}
+
+	r.add(id, aliases, sorter, slot)
+	r.notify(append(events, service.NewSorterEvent(eventType, r.sorters[id]))...)
+	return nil
+}
+
+// add registers sorter under id, listing it at slot of the registration
+// order unless id is already listed.
+func (r *SorterRegistry) add(id string, aliases []string, sorter service.Sorter, slot int) {
+	r.removeAliases(id)
+
+	if _, exists := r.sorters[id]; !exists {
+		r.registered = slices.Insert(r.registered, slot, id)
+	}
+	r.sorters[id] = newDeterministicSorter(sorter)
+	for _, alias := range aliases {
+		r.aliases[alias] = id
+	}
+}
+
+func (r *SorterRegistry) remove(id string) {
+	delete(r.sorters, id)
+	r.registered = slices.DeleteFunc(r.registered, func(registered string) bool { return registered == id })
+	r.removeAliases(id)
+}
+
+// conflicts returns the IDs of the registered sorters that id or one of
+// aliases already refers to.
+func (r *SorterRegistry) conflicts(id string, aliases []string) []string {
+	var ids []string
+	for _, name := range append([]string{id}, aliases...) {
+		if _, exists := r.sorters[name]; !exists {
+			if _, aliased := r.aliases[name]; !aliased {
+				continue
+			}
+		}
+
+		if existing := r.resolve(name); !slices.Contains(ids, existing) {
+			ids = append(ids, existing)
+		}
+	}
+	return ids
+}
+
+// GetSorter looks name up as a sorter ID first and as an alias otherwise.
 func (r *SorterRegistry) GetSorter(name string) (service.Sorter, bool) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	sorter, exists := r.sorters[name]
+	sorter, exists := r.sorters[r.resolve(name)]
 	return sorter, exists
 }
 
+// GetAllSorters returns the sorters listed by SetOrder in that order, followed
+// by the others by metadata priority and then in registration order.
 func (r *SorterRegistry) GetAllSorters() []service.Sorter {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	sorters := make([]service.Sorter, 0, len(r.sorters))
-	for _, sorter := range r.sorters {
-		sorters = append(sorters, sorter)
+	sorters := make([]service.Sorter, len(r.registered))
+	for i, id := range r.registered {
+		sorters[i] = r.sorters[id]
+	}
+
+	return orderSorters(sorters, r.order, r.resolve)
+}
+
+// orderSorters stable-sorts sorters by their position in order, whose names
+// resolve maps to IDs, and then by metadata priority.
+func orderSorters(sorters []service.Sorter, order []string, resolve func(name string) string) []service.Sorter {
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		id := resolve(name)
+		if _, seen := rank[id]; !seen {
+			rank[id] = i
+		}
+	}
+
+	type listedSorter struct {
+		sorter   service.Sorter
+		rank     int
+		priority int
+	}
+
+	listed := make([]listedSorter, 0, len(sorters))
+	for _, sorter := range sorters {
+		position, ok := rank[service.SorterID(sorter)]
+		if !ok {
+			position = len(order)
+		}
+		listed = append(listed, listedSorter{sorter: sorter, rank: position, priority: service.DescribeSorter(sorter).Priority})
+	}
+
+	slices.SortStableFunc(listed, func(a, b listedSorter) int {
+		if c := cmp.Compare(a.rank, b.rank); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.priority, b.priority)
+	})
+
+	ordered := make([]service.Sorter, len(listed))
+	for i, l := range listed {
+		ordered[i] = l.sorter
 	}
 
-	return sorters
+	return ordered
+}
+
+// SetOrder lists the IDs or aliases of the sorters GetAllSorters returns
+// first, in order.
+func (r *SorterRegistry) SetOrder(order []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.order = slices.Clone(order)
 }
 
 func (r *SorterRegistry) UnregisterSorter(name string) bool {
 	r.mutex.Lock()
+
+	id := r.resolve(name)
+
+	sorter, exists := r.sorters[id]
+	if !exists {
+		r.mutex.Unlock()
+		return false
+	}
+
+	r.remove(id)
+	r.notify(service.NewSorterEvent(service.SorterUnregistered, sorter))
+	return true
+}
+
+// Subscribe calls observer after every later registration, replacement and
+// unregistration until the returned function is called. Observers are called
+// one at a time, in the order the changes were made, without the registry
+// locked, so they may read it. They must not register or unregister sorters
+// themselves.
+func (r *SorterRegistry) Subscribe(observer service.SorterObserver) func() {
+	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	_, exists := r.sorters[name]
-	if exists {
-		delete(r.sorters, name)
-		return true
+	id := r.nextObserverID
+	r.nextObserverID++
+	r.observers = append(r.observers, subscription{id: id, observer: observer})
+
+	return func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		r.observers = slices.DeleteFunc(r.observers, func(s subscription) bool { return s.id == id })
 	}
+}
+
+// Events is Subscribe for a channel with the given buffer size. Once the
+// buffer is full, delivery of later events waits for the events to be
+// received. Calling the returned function unsubscribes and closes the channel.
+func (r *SorterRegistry) Events(buffer int) (<-chan service.SorterEvent, func()) {
+	events := make(chan service.SorterEvent, buffer)
+	done := make(chan struct{})
+
+	var (
+		sendMutex sync.Mutex
+		closed    bool
+	)
+
+	unsubscribe := r.Subscribe(func(event service.SorterEvent) {
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+
+		if closed {
+			return
+		}
+		select {
+		case events <- event:
+		case <-done:
+		}
+	})
 
-	return false
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			unsubscribe()
+			close(done)
+
+			sendMutex.Lock()
+			defer sendMutex.Unlock()
+
+			closed = true
+			close(events)
+		})
+	}
+}
+
+// notify queues events for the current observers and releases r.mutex, which
+// the caller holds. Unless another goroutine is already delivering, it then
+// delivers the queued events, including those queued meanwhile, one at a time.
+func (r *SorterRegistry) notify(events ...service.SorterEvent) {
+	for _, event := range events {
+		r.pending = append(r.pending, pendingEvent{event: event, observers: slices.Clone(r.observers)})
+	}
+	if r.delivering {
+		r.mutex.Unlock()
+		return
+	}
+	r.delivering = true
+	r.mutex.Unlock()
+
+	for {
+		r.mutex.Lock()
+		if len(r.pending) == 0 {
+			r.delivering = false
+			r.mutex.Unlock()
+			return
+		}
+		next := r.pending[0]
+		r.pending = r.pending[1:]
+		r.mutex.Unlock()
+
+		for _, s := range next.observers {
+			s.observer(next.event)
+		}
+	}
+}
+
+func (r *SorterRegistry) listingOrder() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return slices.Clone(r.order)
+}
+
+func (r *SorterRegistry) resolve(name string) string {
+	if _, exists := r.sorters[name]; exists {
+		return name
+	}
+	if id, exists := r.aliases[name]; exists {
+		return id
+	}
+	return name
+}
+
+func (r *SorterRegistry) removeAliases(id string) {
+	for alias, target := range r.aliases {
+		if target == id {
+			delete(r.aliases, alias)
+		}
+	}
 }
@@ -0,0 +1,209 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"assessment/domain/service"
+)
+
+// TenantSorterRegistry holds a global SorterRegistry shared by all tenants
+// and a namespace per added tenant layered on top of it.
+type TenantSorterRegistry struct {
+	global  *SorterRegistry
+	tenants map[string]*SorterRegistry
+
+	// empty stands in for the namespace of unknown tenants. Nothing is ever
+	// registered in it, so looking up any number of tenant IDs stores
+	// nothing.
+	empty *SorterRegistry
+
+	mutex sync.RWMutex
+}
+
+func NewTenantSorterRegistry() *TenantSorterRegistry {
+	return &TenantSorterRegistry{
+		global:  NewSorterRegistry(),
+		tenants: make(map[string]*SorterRegistry),
+		empty:   NewSorterRegistry(),
+	}
+}
+
+func (r *TenantSorterRegistry) Global() service.SorterRegistry {
+	return r.global
+}
+
+// Tenant returns the registry of tenantID. Sorters registered through it are
+// visible to that tenant only and replace global sorters with the same ID.
+// Global sorters cannot be unregistered through it; disable them in the
+// tenant's config instead.
+//
+// Tenants that were not added get a read-only view of the global registry, on
+// which Register fails with service.ErrUnknownTenant.
+func (r *TenantSorterRegistry) Tenant(tenantID string) service.SorterRegistry {
+	r.mutex.RLock()
+	namespace, exists := r.tenants[tenantID]
+	r.mutex.RUnlock()
+
+	if !exists {
+		return &tenantRegistry{global: r.global, tenant: r.empty, id: tenantID, readOnly: true}
+	}
+	return &tenantRegistry{global: r.global, tenant: namespace, id: tenantID}
+}
+
+// AddTenant creates the namespace of tenantID unless it exists and returns the
+// registry of the tenant.
+func (r *TenantSorterRegistry) AddTenant(tenantID string) service.SorterRegistry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	namespace, exists := r.tenants[tenantID]
+	if !exists {
+		namespace = NewSorterRegistry()
+		r.tenants[tenantID] = namespace
+	}
+	return &tenantRegistry{global: r.global, tenant: namespace, id: tenantID}
+}
+
+// Tenants returns the IDs of the tenants with a namespace, sorted.
+func (r *TenantSorterRegistry) Tenants() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	ids := make([]string, 0, len(r.tenants))
+	for id := range r.tenants {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// RemoveTenant drops the namespace of tenantID and every sorter registered in
+// it.
+func (r *TenantSorterRegistry) RemoveTenant(tenantID string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.tenants[tenantID]
+	delete(r.tenants, tenantID)
+	return exists
+}
+
+// tenantRegistry is the view of one tenant: its own namespace on top of the
+// global registry. The view of an unknown tenant is read-only.
+type tenantRegistry struct {
+	global *SorterRegistry
+	tenant *SorterRegistry
+
+	id       string
+	readOnly bool
+}
+
+func (r *tenantRegistry) RegisterSorter(sorter service.Sorter) {
+	_ = r.Register(sorter, service.ConflictReplace)
+}
+
+// Register checks for conflicts within the tenant's namespace only, so a
+// tenant may replace a global sorter under any policy.
+func (r *tenantRegistry) Register(sorter service.Sorter, policy service.ConflictPolicy) error {
+	if r.readOnly {
+		return fmt.Errorf("%w: %s", service.ErrUnknownTenant, r.id)
+	}
+	return r.tenant.Register(sorter, policy)
+}
+
+// GetSorter looks name up in the tenant's namespace first. A global sorter
+// found by name or alias is replaced by the tenant's sorter with its ID, if
+// any.
+func (r *tenantRegistry) GetSorter(name string) (service.Sorter, bool) {
+	if sorter, exists := r.tenant.GetSorter(name); exists {
+		return sorter, true
+	}
+
+	sorter, exists := r.global.GetSorter(name)
+	if !exists {
+		return nil, false
+	}
+
+	if override, exists := r.tenant.GetSorter(service.SorterID(sorter)); exists {
+		return override, true
+	}
+	return sorter, true
+}
+
+// GetAllSorters lists the global sorters, with the tenant's replacements in
+// their place, and the tenant's own sorters, in the tenant's SetOrder or else
+// the global one.
+func (r *tenantRegistry) GetAllSorters() []service.Sorter {
+	globalSorters := r.global.GetAllSorters()
+	tenantSorters := r.tenant.GetAllSorters()
+
+	overrides := make(map[string]service.Sorter, len(tenantSorters))
+	for _, sorter := range tenantSorters {
+		overrides[service.SorterID(sorter)] = sorter
+	}
+
+	merged := make([]service.Sorter, 0, len(globalSorters)+len(tenantSorters))
+	for _, sorter := range globalSorters {
+		id := service.SorterID(sorter)
+		if override, exists := overrides[id]; exists {
+			merged = append(merged, override)
+			delete(overrides, id)
+			continue
+		}
+		merged = append(merged, sorter)
+	}
+	for _, sorter := range tenantSorters {
+		if _, exists := overrides[service.SorterID(sorter)]; exists {
+			merged = append(merged, sorter)
+		}
+	}
+
+	order := r.tenant.listingOrder()
+	if len(order) == 0 {
+		order = r.global.listingOrder()
+	}
+
+	return orderSorters(merged, order, func(name string) string {
+		if sorter, exists := r.GetSorter(name); exists {
+			return service.SorterID(sorter)
+		}
+		return name
+	})
+}
+
+// UnregisterSorter removes a sorter from the tenant's namespace, which
+// reveals the global sorter it replaced, if any.
+func (r *tenantRegistry) UnregisterSorter(name string) bool {
+	if r.readOnly {
+		return false
+	}
+	if sorter, exists := r.GetSorter(name); exists {
+		return r.tenant.UnregisterSorter(service.SorterID(sorter))
+	}
+	return false
+}
+
+func (r *tenantRegistry) SetOrder(order []string) {
+	if r.readOnly {
+		return
+	}
+	r.tenant.SetOrder(order)
+}
+
+// Subscribe reports changes to both the tenant's namespace and the global
+// registry.
+func (r *tenantRegistry) Subscribe(observer service.SorterObserver) func() {
+	if r.readOnly {
+		return r.global.Subscribe(observer)
+	}
+
+	unsubscribeTenant := r.tenant.Subscribe(observer)
+	unsubscribeGlobal := r.global.Subscribe(observer)
+
+	return func() {
+		unsubscribeTenant()
+		unsubscribeGlobal()
+	}
+}
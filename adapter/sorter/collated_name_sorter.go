@@ -0,0 +1,66 @@
+package sorter
+
+import (
+	"bytes"
+	"fmt"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+// CollatedNameSorter orders products by name using the collation rules of a
+// locale, so accented letters sort next to their base letter and
+// locale-specific letters such as Swedish "å" land where customers expect.
+type CollatedNameSorter struct {
+	*FieldSorter[[]byte]
+	locale language.Tag
+}
+
+func NewCollatedNameSorter(ascending bool, locale string) (*CollatedNameSorter, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collation locale %q: %w", locale, err)
+	}
+
+	name := fmt.Sprintf("Name (descending, %s)", tag)
+	if ascending {
+		name = fmt.Sprintf("Name (ascending, %s)", tag)
+	}
+
+	s := &CollatedNameSorter{locale: tag}
+	s.FieldSorter = newCatalogFieldSorter(name, s.keys, bytes.Compare, ascending).
+		identify(sorterID("name_"+slugID(tag.String()), ascending), localizedLabel("%s (%s, %s)", "Name", directionLabel(ascending), tag.String())).
+		describe(CategoryName, priorityCollatedName, directionalDescription(ascending,
+			fmt.Sprintf("Alphabetical by name in %s order", tag), fmt.Sprintf("Reverse alphabetical by name in %s order", tag)))
+	return s, nil
+}
+
+// keys builds collation keys with a fresh collator on every call because a
+// collate.Collator must not be shared between goroutines.
+func (s *CollatedNameSorter) keys(products model.ProductList) [][]byte {
+	collator := collate.New(s.locale)
+
+	var buf collate.Buffer
+	keys := make([][]byte, len(products))
+	for i, p := range products {
+		keys[i] = bytes.Clone(collator.KeyFromString(&buf, p.Name))
+	}
+	return keys
+}
+
+// Explain reports product names as keys rather than their binary collation
+// keys.
+func (s *CollatedNameSorter) Explain(products model.ProductList) []service.RankExplanation {
+	explanations := s.FieldSorter.Explain(products)
+	for i := range explanations {
+		explanations[i].Key = explanations[i].Product.Name
+	}
+	return explanations
+}
+
+func (s *CollatedNameSorter) Locale() string {
+	return s.locale.String()
+}
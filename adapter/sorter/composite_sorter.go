@@ -0,0 +1,349 @@
+package sorter
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+type SortKey struct {
+	Field     string
+	Ascending bool
+}
+
+// sortField extracts the keys of a field once per product, so that sorting
+// compares precomputed keys rather than recomputing them on every comparison.
+type sortField struct {
+	label   string
+	extract func(products model.ProductList) fieldKeys
+}
+
+// fieldKeys holds a field's keys of a list of products, addressed by index.
+type fieldKeys interface {
+	compare(i, j int) int
+	value(i int) any
+}
+
+type keyColumn[K any] struct {
+	keys    []K
+	cmp     func(a, b K) int
+	display func(i int) any
+}
+
+func (c *keyColumn[K]) compare(i, j int) int {
+	return c.cmp(c.keys[i], c.keys[j])
+}
+
+func (c *keyColumn[K]) value(i int) any {
+	if c.display != nil {
+		return c.display(i)
+	}
+	return c.keys[i]
+}
+
+func extractKeys[K any](key func(p *model.Product) K, compare func(a, b K) int) func(model.ProductList) fieldKeys {
+	return func(products model.ProductList) fieldKeys {
+		keys := make([]K, len(products))
+		for i, p := range products {
+			keys[i] = key(p)
+		}
+		return &keyColumn[K]{keys: keys, cmp: compare}
+	}
+}
+
+var sortFields = map[string]sortField{
+	"price": {
+		label:   "Price",
+		extract: extractKeys(func(p *model.Product) float64 { return p.Price }, cmp.Compare[float64]),
+	},
+	"created": {
+		label:   "Creation Date",
+		extract: extractKeys(func(p *model.Product) time.Time { return p.Created }, time.Time.Compare),
+	},
+	"name": {
+		label: "Name",
+		// Names compare case-insensitively but are reported as they are.
+		extract: func(products model.ProductList) fieldKeys {
+			keys := make([]string, len(products))
+			for i, p := range products {
+				keys[i] = strings.ToLower(p.Name)
+			}
+			return &keyColumn[string]{keys: keys, cmp: strings.Compare, display: func(i int) any { return products[i].Name }}
+		},
+	},
+	"sales_per_view": {
+		label:   "Sales per View",
+		extract: extractKeys(calculateSalesPerView, cmp.Compare[float64]),
+	},
+	"sales": {
+		label:   "Sales",
+		extract: extractKeys(func(p *model.Product) int { return p.SalesCount }, cmp.Compare[int]),
+	},
+	"views": {
+		label:   "Views",
+		extract: extractKeys(func(p *model.Product) int { return p.ViewsCount }, cmp.Compare[int]),
+	},
+	"id": {
+		label:   "ID",
+		extract: extractKeys(func(p *model.Product) int { return p.ID }, cmp.Compare[int]),
+	},
+}
+
+// fieldAliases maps the legacy config keys and common shorthands onto sortFields.
+var fieldAliases = map[string]string{
+	"creation_date": "created",
+	"date":          "created",
+	"spv":           "sales_per_view",
+	"sales_count":   "sales",
+	"views_count":   "views",
+}
+
+func lookupSortField(field string) (string, sortField, bool) {
+	key := strings.ToLower(strings.TrimSpace(field))
+	if alias, ok := fieldAliases[key]; ok {
+		key = alias
+	}
+	f, ok := sortFields[key]
+	return key, f, ok
+}
+
+type CompositeSorter struct {
+	name        string
+	named       bool
+	id          string
+	description string
+	keys        []SortKey
+	labels      []string
+	fields      []sortField
+}
+
+// compositeKeys holds the keys of every field of a list of products.
+type compositeKeys struct {
+	products model.ProductList
+	columns  []fieldKeys
+}
+
+func NewCompositeSorter(name string, keys ...SortKey) (*CompositeSorter, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("composite sorter requires at least one sort key")
+	}
+
+	s := &CompositeSorter{
+		keys:   make([]SortKey, 0, len(keys)),
+		labels: make([]string, 0, len(keys)),
+		fields: make([]sortField, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		canonical, field, ok := lookupSortField(key.Field)
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field: %s", key.Field)
+		}
+
+		s.keys = append(s.keys, SortKey{Field: canonical, Ascending: key.Ascending})
+		s.fields = append(s.fields, field)
+		s.labels = append(s.labels, fmt.Sprintf("%s (%s)", field.label, directionLabel(key.Ascending)))
+	}
+
+	// Unnamed sorters are identified by their keys in sort expression form.
+	s.name = name
+	s.named = name != ""
+	s.id = slugID(name)
+	if !s.named {
+		s.name = s.derivedLabel("")
+		ids := make([]string, len(s.keys))
+		for i, key := range s.keys {
+			ids[i] = sorterID(key.Field, key.Ascending)
+		}
+		s.id = strings.Join(ids, ",")
+	}
+
+	return s, nil
+}
+
+func (s *CompositeSorter) Sort(products model.ProductList) model.ProductList {
+
+	keyed := s.extract(products)
+	indices := keyed.indices()
+
+	sortStable(indices, keyed.compare(s.keys))
+
+	return keyed.collect(indices)
+}
+
+// SortContext is Sort that gives up once ctx is done, checking it after the
+// keys are extracted and between the rounds of the sort.
+func (s *CompositeSorter) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	keyed := s.extract(products)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	indices := keyed.indices()
+	if err := sortStableContext(ctx, indices, keyed.compare(s.keys)); err != nil {
+		return nil, err
+	}
+	return keyed.collect(indices), nil
+}
+
+func (s *CompositeSorter) SortTopK(products model.ProductList, k int) model.ProductList {
+	keyed := s.extract(products)
+	return keyed.collect(selectTopK(keyed.indices(), k, keyed.compare(s.keys)))
+}
+
+// Explain reports each product's value for every sort key in order.
+func (s *CompositeSorter) Explain(products model.ProductList) []service.RankExplanation {
+	keyed := s.extract(products)
+	indices := keyed.indices()
+	sortStable(indices, keyed.compare(s.keys))
+
+	sorted := make(model.ProductList, len(indices))
+	for i, index := range indices {
+		sorted[i] = products[index]
+	}
+
+	explanations := newExplanations(sorted)
+	for i, index := range indices {
+		components := make([]service.ExplanationComponent, len(keyed.columns))
+		for f, column := range keyed.columns {
+			components[f] = service.ExplanationComponent{Name: s.labels[f], Value: column.value(index)}
+		}
+		explanations[i].Components = components
+	}
+
+	markTies(explanations, func(i, j int) bool {
+		for _, column := range keyed.columns {
+			if column.compare(indices[i], indices[j]) != 0 {
+				return false
+			}
+		}
+		return true
+	})
+	return explanations
+}
+
+func (s *CompositeSorter) extract(products model.ProductList) *compositeKeys {
+	columns := make([]fieldKeys, len(s.fields))
+	for i, field := range s.fields {
+		columns[i] = field.extract(products)
+	}
+	return &compositeKeys{products: products, columns: columns}
+}
+
+func (k *compositeKeys) indices() []int {
+	indices := make([]int, len(k.products))
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+// compare orders product indices by the columns in the directions of keys,
+// breaking ties on product ID.
+func (k *compositeKeys) compare(keys []SortKey) func(a, b int) int {
+	return func(a, b int) int {
+		for f, column := range k.columns {
+			c := column.compare(a, b)
+			if !keys[f].Ascending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(k.products[a].ID, k.products[b].ID)
+	}
+}
+
+func (k *compositeKeys) collect(indices []int) model.ProductList {
+	ordered := make(model.ProductList, len(indices))
+	for i, index := range indices {
+		ordered[i] = k.products[index]
+	}
+	return ordered.Clone()
+}
+
+func (s *CompositeSorter) BreaksTiesByID() bool {
+	return true
+}
+
+func (s *CompositeSorter) Keys() []SortKey {
+	keys := make([]SortKey, len(s.keys))
+	copy(keys, s.keys)
+	return keys
+}
+
+func (s *CompositeSorter) Name() string {
+	return s.name
+}
+
+func (s *CompositeSorter) ID() string {
+	if s.id == "" {
+		return s.name
+	}
+	return s.id
+}
+
+func (s *CompositeSorter) SetID(id string) {
+	s.id = id
+}
+
+// Direction is the direction of the first sort key.
+func (s *CompositeSorter) Direction() service.SortDirection {
+	if s.keys[0].Ascending {
+		return service.SortAscending
+	}
+	return service.SortDescending
+}
+
+// Label returns the name given to the sorter, or its keys described in the
+// language of locale when it has none.
+func (s *CompositeSorter) Label(locale string) string {
+	if s.named {
+		return s.name
+	}
+	return s.derivedLabel(locale)
+}
+
+func (s *CompositeSorter) Metadata() service.SorterMetadata {
+	description := s.description
+	if description == "" {
+		description = "Sorted by " + s.derivedLabel("")
+	}
+
+	return service.SorterMetadata{
+		ID:          s.ID(),
+		Description: description,
+		Category:    CategoryCustom,
+		Direction:   s.Direction(),
+		Priority:    priorityComposite,
+	}
+}
+
+func (s *CompositeSorter) SetDescription(description string) {
+	s.description = description
+}
+
+func (s *CompositeSorter) derivedLabel(locale string) string {
+	labels := make([]string, len(s.keys))
+	for i, key := range s.keys {
+		labels[i] = fmt.Sprintf("%s (%s)", translate(locale, s.fields[i].label), translate(locale, directionLabel(key.Ascending)))
+	}
+	return strings.Join(labels, ", "+translate(locale, "then")+" ")
+}
+
+func directionLabel(ascending bool) string {
+	if ascending {
+		return "ascending"
+	}
+	return "descending"
+}
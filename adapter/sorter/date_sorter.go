@@ -1,38 +1,25 @@
 package sorter
 
 import (
-	"sort"
+	"time"
 
 	"assessment/domain/model"
 )
 
 type DateSorter struct {
-	ascending bool
+	*FieldSorter[time.Time]
 }
 
 func NewDateSorter(ascending bool) *DateSorter {
-	return &DateSorter{
-		ascending: ascending,
+	name := "Creation Date (descending)"
+	if ascending {
+		name = "Creation Date (ascending)"
 	}
-}
-
-func (s *DateSorter) Sort(products model.ProductList) model.ProductList {
-
-	result := products.Clone()
 
-	sort.Slice(result, func(i, j int) bool {
-		if s.ascending {
-			return result[i].Created.Before(result[j].Created)
-		}
-		return result[i].Created.After(result[j].Created)
-	})
-
-	return result
-}
-
-func (s *DateSorter) Name() string {
-	if s.ascending {
-		return "Creation Date (ascending)"
+	return &DateSorter{
+		FieldSorter: NewFieldSorterFunc(name, func(p *model.Product) time.Time { return p.Created }, time.Time.Compare, ascending).
+			identify(sorterID("created", ascending), directionalLabel("Creation Date", ascending),
+				sorterID("creation_date", ascending), sorterID("date", ascending)).
+			describe(CategoryDate, priorityDate, directionalDescription(ascending, "Oldest products first", "Newest products first")),
 	}
-	return "Creation Date (descending)"
 }
@@ -0,0 +1,50 @@
+package sorter
+
+import (
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+// newExplanations numbers sorted products and clones them, as Sort does.
+func newExplanations(sorted model.ProductList) []service.RankExplanation {
+	cloned := sorted.Clone()
+
+	explanations := make([]service.RankExplanation, len(cloned))
+	for i, p := range cloned {
+		explanations[i] = service.RankExplanation{Position: i + 1, Product: p}
+	}
+	return explanations
+}
+
+// markTies records the runs of adjacent explanations whose keys are equal, as
+// reported by tied, and which were therefore ordered by product ID. Each
+// explanation lists its nearest neighbors in the run, up to
+// service.MaxTiedWith of them.
+func markTies(explanations []service.RankExplanation, tied func(i, j int) bool) {
+	for start := 0; start < len(explanations); {
+		end := start + 1
+		for end < len(explanations) && tied(start, end) {
+			end++
+		}
+
+		if size := end - start; size > 1 {
+			for i := start; i < end; i++ {
+				from := max(start, min(i-service.MaxTiedWith/2, end-service.MaxTiedWith-1))
+				to := min(end, from+service.MaxTiedWith+1)
+
+				tiedWith := make([]int, 0, to-from-1)
+				for j := from; j < to; j++ {
+					if i != j {
+						tiedWith = append(tiedWith, explanations[j].Product.ID)
+					}
+				}
+
+				explanations[i].TieGroupSize = size
+				explanations[i].TiedWith = tiedWith
+				explanations[i].TieBreak = service.TieBreakProductID
+			}
+		}
+
+		start = end
+	}
+}
@@ -0,0 +1,238 @@
+package sorter
+
+import (
+	"cmp"
+	"context"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+// FieldSorter orders products by a key extracted once per product, breaking
+// ties on product ID. It is the building block of the built-in sorters and
+// can be reused by third-party sorters through NewFieldSorter.
+type FieldSorter[K any] struct {
+	name      string
+	ascending bool
+	keys      func(products model.ProductList) []K
+	compare   func(a, b K) int
+
+	id      string
+	label   func(locale string) string
+	aliases []string
+
+	description string
+	category    string
+	priority    int
+}
+
+type keyedProduct[K any] struct {
+	product *model.Product
+	key     K
+}
+
+func NewFieldSorter[K cmp.Ordered](name string, key func(*model.Product) K, ascending bool) *FieldSorter[K] {
+	return NewFieldSorterFunc(name, key, cmp.Compare[K], ascending)
+}
+
+// NewFieldSorterFunc is NewFieldSorter for keys that are not cmp.Ordered,
+// such as time.Time, compared with the given function.
+func NewFieldSorterFunc[K any](name string, key func(*model.Product) K, compare func(a, b K) int, ascending bool) *FieldSorter[K] {
+	return newCatalogFieldSorter(name, func(products model.ProductList) []K {
+		keys := make([]K, len(products))
+		for i, p := range products {
+			keys[i] = key(p)
+		}
+		return keys
+	}, compare, ascending)
+}
+
+// newCatalogFieldSorter builds a FieldSorter whose keys depend on the whole
+// list being sorted, such as normalized scores or catalog-wide priors.
+func newCatalogFieldSorter[K any](name string, keys func(model.ProductList) []K, compare func(a, b K) int, ascending bool) *FieldSorter[K] {
+	return &FieldSorter[K]{
+		name:      name,
+		ascending: ascending,
+		keys:      keys,
+		compare:   compare,
+	}
+}
+
+func (s *FieldSorter[K]) Sort(products model.ProductList) model.ProductList {
+
+	items := s.keyed(products)
+
+	sortStable(items, s.compareKeyed)
+
+	return collectProducts(items)
+}
+
+// SortContext is Sort that gives up once ctx is done, checking it after the
+// keys are extracted and between the rounds of the sort.
+func (s *FieldSorter[K]) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	items := s.keyed(products)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := sortStableContext(ctx, items, s.compareKeyed); err != nil {
+		return nil, err
+	}
+	return collectProducts(items), nil
+}
+
+func (s *FieldSorter[K]) SortTopK(products model.ProductList, k int) model.ProductList {
+	return collectProducts(selectTopK(s.keyed(products), k, s.compareKeyed))
+}
+
+func (s *FieldSorter[K]) Explain(products model.ProductList) []service.RankExplanation {
+	return s.explain(products, nil)
+}
+
+// explain sorts like Sort and reports each product's key. components, if set,
+// returns the breakdown of the key of the product at the given input index.
+func (s *FieldSorter[K]) explain(products model.ProductList, components func(i int) []service.ExplanationComponent) []service.RankExplanation {
+	type indexedProduct struct {
+		keyedProduct[K]
+		index int
+	}
+
+	keyed := s.keyed(products)
+	items := make([]indexedProduct, len(keyed))
+	for i, item := range keyed {
+		items[i] = indexedProduct{keyedProduct: item, index: i}
+	}
+
+	sortStable(items, func(a, b indexedProduct) int { return s.compareKeyed(a.keyedProduct, b.keyedProduct) })
+
+	sorted := make(model.ProductList, len(items))
+	for i, item := range items {
+		sorted[i] = item.product
+	}
+
+	explanations := newExplanations(sorted)
+	for i, item := range items {
+		explanations[i].Key = item.key
+		if components != nil {
+			explanations[i].Components = components(item.index)
+		}
+	}
+
+	markTies(explanations, func(i, j int) bool { return s.compare(items[i].key, items[j].key) == 0 })
+	return explanations
+}
+
+func (s *FieldSorter[K]) keyed(products model.ProductList) []keyedProduct[K] {
+	keys := s.keys(products)
+
+	items := make([]keyedProduct[K], len(products))
+	for i, p := range products {
+		items[i] = keyedProduct[K]{product: p, key: keys[i]}
+	}
+	return items
+}
+
+func (s *FieldSorter[K]) compareKeyed(a, b keyedProduct[K]) int {
+	c := s.compare(a.key, b.key)
+	if !s.ascending {
+		c = -c
+	}
+	if c == 0 {
+		return cmp.Compare(a.product.ID, b.product.ID)
+	}
+	return c
+}
+
+func (s *FieldSorter[K]) BreaksTiesByID() bool {
+	return true
+}
+
+func (s *FieldSorter[K]) Ascending() bool {
+	return s.ascending
+}
+
+// ID returns the ID set with SetID, or the sorter's name when none was set.
+func (s *FieldSorter[K]) ID() string {
+	if s.id == "" {
+		return s.name
+	}
+	return s.id
+}
+
+func (s *FieldSorter[K]) SetID(id string) {
+	s.id = id
+}
+
+func (s *FieldSorter[K]) Direction() service.SortDirection {
+	if s.ascending {
+		return service.SortAscending
+	}
+	return service.SortDescending
+}
+
+func (s *FieldSorter[K]) Label(locale string) string {
+	if s.label == nil {
+		return s.name
+	}
+	return s.label(locale)
+}
+
+func (s *FieldSorter[K]) Aliases() []string {
+	return s.aliases
+}
+
+func (s *FieldSorter[K]) Metadata() service.SorterMetadata {
+	return service.SorterMetadata{
+		ID:          s.ID(),
+		Description: s.description,
+		Category:    s.category,
+		Direction:   s.Direction(),
+		Priority:    s.priority,
+	}
+}
+
+// SetDescription sets the description reported in the sorter's metadata.
+func (s *FieldSorter[K]) SetDescription(description string) {
+	s.description = description
+}
+
+// describe sets the metadata of a built-in sorter.
+func (s *FieldSorter[K]) describe(category string, priority int, description string) *FieldSorter[K] {
+	s.category = category
+	s.priority = priority
+	s.description = description
+	return s
+}
+
+// identify sets the ID, localized label and aliases of a built-in sorter.
+func (s *FieldSorter[K]) identify(id string, label func(locale string) string, aliases ...string) *FieldSorter[K] {
+	s.id = id
+	s.label = label
+	s.aliases = aliases
+	return s
+}
+
+// rename gives a built-in sorter a custom name, which also becomes its label
+// and, in snake case, its ID.
+func (s *FieldSorter[K]) rename(name string) {
+	s.name = name
+	s.id = slugID(name)
+	s.label = nil
+	s.aliases = nil
+}
+
+func (s *FieldSorter[K]) Name() string {
+	return s.name
+}
+
+func collectProducts[K any](items []keyedProduct[K]) model.ProductList {
+	ordered := make(model.ProductList, len(items))
+	for i, item := range items {
+		ordered[i] = item.product
+	}
+	return ordered.Clone()
+}
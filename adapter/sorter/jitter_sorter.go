@@ -0,0 +1,150 @@
+package sorter
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+// JitterSorter perturbs the order of another sorter: each product's position
+// is offset by a seeded random amount below the window, so products only trade
+// places with neighbors fewer than window positions away.
+type JitterSorter struct {
+	sorter service.Sorter
+	window int
+	seed   int64
+}
+
+func NewJitterSorter(sorter service.Sorter, window int, seed int64) (*JitterSorter, error) {
+	if sorter == nil {
+		return nil, fmt.Errorf("jitter sorter requires a sorter to perturb")
+	}
+	if window < 1 {
+		return nil, fmt.Errorf("jitter window must be at least 1, got %d", window)
+	}
+
+	return &JitterSorter{
+		sorter: sorter,
+		window: window,
+		seed:   seed,
+	}, nil
+}
+
+func (s *JitterSorter) Sort(products model.ProductList) model.ProductList {
+	return jitter(s.sorter.Sort(products), s.window, s.seed)
+}
+
+func (s *JitterSorter) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	sorted, err := service.AsSorterV2(s.sorter).SortContext(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+	return jitter(sorted, s.window, shuffleSeedFromContext(ctx, s.seed)), nil
+}
+
+// Explain explains the perturbed sorter's ranking and records how far each
+// product was moved.
+func (s *JitterSorter) Explain(products model.ProductList) []service.RankExplanation {
+	explanations := service.Explain(s.sorter, products)
+
+	sorted := make(model.ProductList, len(explanations))
+	for i, e := range explanations {
+		sorted[i] = e.Product
+	}
+
+	jittered := make([]service.RankExplanation, len(explanations))
+	for i, from := range jitterOrder(sorted, s.window, s.seed) {
+		e := explanations[from]
+		e.Position = i + 1
+		if from != i {
+			e.Adjustments = append(e.Adjustments, fmt.Sprintf("jittered from position %d", from+1))
+		}
+		jittered[i] = e
+	}
+	return jittered
+}
+
+func (s *JitterSorter) Name() string {
+	return fmt.Sprintf("%s (jitter %d)", s.sorter.Name(), s.window)
+}
+
+func (s *JitterSorter) ID() string {
+	return fmt.Sprintf("%s+jitter%d", service.SorterID(s.sorter), s.window)
+}
+
+func (s *JitterSorter) Direction() service.SortDirection {
+	return service.SorterDirection(s.sorter)
+}
+
+func (s *JitterSorter) Label(locale string) string {
+	return fmt.Sprintf("%s (%s %d)", service.SorterLabel(s.sorter, locale), translate(locale, "jitter"), s.window)
+}
+
+func (s *JitterSorter) Metadata() service.SorterMetadata {
+	base := service.DescribeSorter(s.sorter).Description
+	if base == "" {
+		base = service.SorterLabel(s.sorter, "")
+	}
+	description := fmt.Sprintf("%s, varied within %d positions", base, s.window)
+
+	return service.SorterMetadata{
+		ID:          s.ID(),
+		Description: description,
+		Category:    CategoryExperiment,
+		Direction:   s.Direction(),
+		Priority:    priorityJitter,
+	}
+}
+
+// BreaksTiesByID reports whether the perturbed sorter does, as jittering
+// depends only on its order and the product IDs.
+func (s *JitterSorter) BreaksTiesByID() bool {
+	tieBreaker, ok := s.sorter.(service.IDTieBreaker)
+	return ok && tieBreaker.BreaksTiesByID()
+}
+
+func (s *JitterSorter) Window() int {
+	return s.window
+}
+
+func (s *JitterSorter) Unwrap() service.Sorter {
+	return s.sorter
+}
+
+func jitter(sorted model.ProductList, window int, seed int64) model.ProductList {
+	result := make(model.ProductList, len(sorted))
+	for i, from := range jitterOrder(sorted, window, seed) {
+		result[i] = sorted[from]
+	}
+	return result.Clone()
+}
+
+// jitterOrder returns the indices of sorted in jittered order.
+func jitterOrder(sorted model.ProductList, window int, seed int64) []int {
+	type jitteredIndex struct {
+		index int
+		key   float64
+	}
+
+	items := make([]jitteredIndex, len(sorted))
+	for i, p := range sorted {
+		u := float64(shuffleKey(seed, p.ID)>>11) / (1 << 53)
+		items[i] = jitteredIndex{index: i, key: float64(i) + u*float64(window)}
+	}
+
+	sortStable(items, func(a, b jitteredIndex) int {
+		if c := cmp.Compare(a.key, b.key); c != 0 {
+			return c
+		}
+		return cmp.Compare(sorted[a.index].ID, sorted[b.index].ID)
+	})
+
+	order := make([]int, len(items))
+	for i, item := range items {
+		order[i] = item.index
+	}
+	return order
+}
@@ -0,0 +1,107 @@
+package sorter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// labelTranslations translates the words built-in sorter labels are made of,
+// keyed by base language. English is the default and needs no entry.
+var labelTranslations = map[string]map[string]string{
+	"de": {
+		"Price":               "Preis",
+		"Creation Date":       "Erstellungsdatum",
+		"Name":                "Name",
+		"Sales per View":      "Verkäufe pro Aufruf",
+		"Smoothed Conversion": "Geglättete Konversion",
+		"Wilson Score":        "Wilson-Score",
+		"Trending":            "Im Trend",
+		"Shuffle":             "Zufällig",
+		"Sales":               "Verkäufe",
+		"Views":               "Aufrufe",
+		"ID":                  "ID",
+		"ascending":           "aufsteigend",
+		"descending":          "absteigend",
+		"natural":             "natürlich",
+		"then":                "dann",
+		"jitter":              "Streuung",
+	},
+	"fr": {
+		"Price":               "Prix",
+		"Creation Date":       "Date de création",
+		"Name":                "Nom",
+		"Sales per View":      "Ventes par vue",
+		"Smoothed Conversion": "Conversion lissée",
+		"Wilson Score":        "Score de Wilson",
+		"Trending":            "Tendance",
+		"Shuffle":             "Aléatoire",
+		"Sales":               "Ventes",
+		"Views":               "Vues",
+		"ID":                  "ID",
+		"ascending":           "croissant",
+		"descending":          "décroissant",
+		"natural":             "naturel",
+		"then":                "puis",
+		"jitter":              "dispersion",
+	},
+}
+
+// translate returns text in the base language of locale, or text itself when
+// there is no translation.
+func translate(locale, text string) string {
+	base, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	base, _, _ = strings.Cut(base, "_")
+
+	if translated, ok := labelTranslations[base][text]; ok {
+		return translated
+	}
+	return text
+}
+
+// localizedLabel returns a label function that formats the translated words
+// with format. Its English output is the sorter's Name.
+func localizedLabel(format string, words ...string) func(locale string) string {
+	return func(locale string) string {
+		args := make([]any, len(words))
+		for i, word := range words {
+			args[i] = translate(locale, word)
+		}
+		return fmt.Sprintf(format, args...)
+	}
+}
+
+func directionalLabel(base string, ascending bool) func(locale string) string {
+	return localizedLabel("%s (%s)", base, directionLabel(ascending))
+}
+
+// sorterID returns the ID of a sorter of the given field and direction, the
+// same form ad-hoc sort expressions use for a single term.
+func sorterID(field string, ascending bool) string {
+	if ascending {
+		return field + ":asc"
+	}
+	return field + ":desc"
+}
+
+// slugID turns a display name into an ID such as "popular_but_cheap".
+func slugID(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// CanonicalSortField returns the canonical field of a sort field name or
+// alias, such as "created" for the legacy config key "creation_date".
+func CanonicalSortField(field string) (string, bool) {
+	canonical, _, ok := lookupSortField(field)
+	return canonical, ok
+}
@@ -0,0 +1,365 @@
+package sorter
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+type FeatureTransform string
+
+const (
+	TransformIdentity    FeatureTransform = "identity"
+	TransformLog         FeatureTransform = "log"
+	TransformSqrt        FeatureTransform = "sqrt"
+	TransformStandardize FeatureTransform = "standardize"
+)
+
+type featureFunc func(p *model.Product, now time.Time) float64
+
+var modelFeatures = map[string]featureFunc{
+	"price":     func(p *model.Product, _ time.Time) float64 { return p.Price },
+	"log_views": func(p *model.Product, _ time.Time) float64 { return math.Log1p(float64(max(p.ViewsCount, 0))) },
+	"sales_per_view": func(p *model.Product, _ time.Time) float64 {
+		return calculateSalesPerView(p)
+	},
+	"age": func(p *model.Product, now time.Time) float64 {
+		return now.Sub(p.Created).Hours() / 24
+	},
+}
+
+// ModelFeature is one term of a linear model. Mean and StdDev are the
+// training-set statistics used by the standardize transform.
+type ModelFeature struct {
+	Name      string           `json:"name"`
+	Weight    float64          `json:"weight"`
+	Transform FeatureTransform `json:"transform,omitempty"`
+	Mean      float64          `json:"mean,omitempty"`
+	StdDev    float64          `json:"std_dev,omitempty"`
+}
+
+// LinearModel is a ranking model trained offline. A product's score is the
+// bias plus the weighted sum of its transformed features.
+type LinearModel struct {
+	Version  string         `json:"version,omitempty"`
+	Features []ModelFeature `json:"features"`
+	Bias     float64        `json:"bias"`
+}
+
+func (m *LinearModel) Validate() error {
+	if len(m.Features) == 0 {
+		return fmt.Errorf("linear model requires at least one feature")
+	}
+
+	for _, f := range m.Features {
+		if _, ok := modelFeatures[f.Name]; !ok {
+			return fmt.Errorf("unknown model feature: %s", f.Name)
+		}
+
+		switch f.Transform {
+		case "", TransformIdentity, TransformLog, TransformSqrt:
+		case TransformStandardize:
+			if f.StdDev <= 0 {
+				return fmt.Errorf("feature %s: standardize requires a positive std_dev", f.Name)
+			}
+		default:
+			return fmt.Errorf("feature %s: unknown transform %q", f.Name, f.Transform)
+		}
+	}
+
+	return nil
+}
+
+// Score returns the model's score for p at the given time.
+func (m *LinearModel) Score(p *model.Product, now time.Time) float64 {
+	score := m.Bias
+	for _, f := range m.Features {
+		score += f.Weight * f.transform(modelFeatures[f.Name](p, now))
+	}
+	return score
+}
+
+func (f ModelFeature) transform(v float64) float64 {
+	switch f.Transform {
+	case TransformLog:
+		return math.Log1p(math.Max(v, 0))
+	case TransformSqrt:
+		return math.Sqrt(math.Max(v, 0))
+	case TransformStandardize:
+		return (v - f.Mean) / f.StdDev
+	default:
+		return v
+	}
+}
+
+func LoadLinearModel(filename string) (*LinearModel, error) {
+
+	// Validate filename to prevent path traversal
+	cleanPath := filepath.Clean(filename)
+	if filepath.IsAbs(cleanPath) || strings.Contains(cleanPath, "..") {
+		return nil, fmt.Errorf("invalid filename path: potential directory traversal attempt")
+	}
+
+	data, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var m LinearModel
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid model file %s: %w", filename, err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid model file %s: %w", filename, err)
+	}
+
+	return &m, nil
+}
+
+// LinearModelSorter ranks products by a LinearModel, highest score first. The
+// model can be swapped while the sorter is in use; every sort runs entirely
+// against the model that was current when it started.
+type LinearModelSorter struct {
+	name        string
+	id          string
+	description string
+	file        string
+	model       atomic.Pointer[LinearModel]
+	clock       Clock
+
+	// reloadMu serializes Reload so a slow read cannot overwrite a newer model.
+	reloadMu sync.Mutex
+	modTime  time.Time
+}
+
+func NewLinearModelSorter(name string, m *LinearModel) (*LinearModelSorter, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("linear model sorter requires a name")
+	}
+
+	s := &LinearModelSorter{
+		name:  name,
+		id:    slugID(name),
+		clock: SystemClock,
+	}
+	if err := s.Swap(m); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewLinearModelSorterFromFile loads the model in filename, which Reload and
+// Watch read again to pick up retrained models.
+func NewLinearModelSorterFromFile(name, filename string) (*LinearModelSorter, error) {
+	m, err := LoadLinearModel(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewLinearModelSorter(name, m)
+	if err != nil {
+		return nil, err
+	}
+
+	s.file = filename
+	if info, err := os.Stat(filepath.Clean(filename)); err == nil {
+		s.modTime = info.ModTime()
+	}
+	return s, nil
+}
+
+// Swap validates m and makes it the model used by subsequent sorts.
+func (s *LinearModelSorter) Swap(m *LinearModel) error {
+	if m == nil {
+		return fmt.Errorf("linear model sorter %q requires a model", s.name)
+	}
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	s.model.Store(m)
+	return nil
+}
+
+// Reload reads the model file again. The current model is kept when the file
+// is invalid.
+func (s *LinearModelSorter) Reload() error {
+	if s.file == "" {
+		return fmt.Errorf("linear model sorter %q was not loaded from a file", s.name)
+	}
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	info, err := os.Stat(filepath.Clean(s.file))
+	if err != nil {
+		return err
+	}
+
+	m, err := LoadLinearModel(s.file)
+	if err != nil {
+		return err
+	}
+
+	s.model.Store(m)
+	s.modTime = info.ModTime()
+	return nil
+}
+
+// Watch reloads the model file whenever its modification time changes,
+// checking every interval until ctx is done. Reload errors are passed to
+// onError, if set, and leave the current model in place.
+func (s *LinearModelSorter) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	if s.file == "" {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(filepath.Clean(s.file))
+		if err == nil && s.modified(info.ModTime()) {
+			err = s.Reload()
+		}
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+func (s *LinearModelSorter) modified(modTime time.Time) bool {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	return !modTime.Equal(s.modTime)
+}
+
+func (s *LinearModelSorter) Sort(products model.ProductList) model.ProductList {
+	return s.fieldSorter().Sort(products)
+}
+
+func (s *LinearModelSorter) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	return s.fieldSorter().SortContext(ctx, products)
+}
+
+func (s *LinearModelSorter) SortTopK(products model.ProductList, k int) model.ProductList {
+	return s.fieldSorter().SortTopK(products, k)
+}
+
+func (s *LinearModelSorter) BreaksTiesByID() bool {
+	return true
+}
+
+// Explain reports each product's score broken down into the bias and the
+// weighted contribution of every feature.
+func (s *LinearModelSorter) Explain(products model.ProductList) []service.RankExplanation {
+	m := s.model.Load()
+	now := s.clock.Now()
+
+	return s.fieldSorterFor(m, now).explain(products, func(i int) []service.ExplanationComponent {
+		components := make([]service.ExplanationComponent, 0, len(m.Features)+1)
+		components = append(components, service.ExplanationComponent{Name: "bias", Value: m.Bias})
+		for _, f := range m.Features {
+			components = append(components, service.ExplanationComponent{
+				Name:  fmt.Sprintf("%s (%s, weight %g)", f.Name, f.transformLabel(), f.Weight),
+				Value: f.Weight * f.transform(modelFeatures[f.Name](products[i], now)),
+			})
+		}
+		return components
+	})
+}
+
+func (f ModelFeature) transformLabel() string {
+	if f.Transform == "" {
+		return string(TransformIdentity)
+	}
+	return string(f.Transform)
+}
+
+func (s *LinearModelSorter) fieldSorter() *FieldSorter[float64] {
+	return s.fieldSorterFor(s.model.Load(), s.clock.Now())
+}
+
+func (s *LinearModelSorter) fieldSorterFor(m *LinearModel, now time.Time) *FieldSorter[float64] {
+	scores := func(products model.ProductList) []float64 {
+		scores := make([]float64, len(products))
+		for i, p := range products {
+			scores[i] = m.Score(p, now)
+		}
+		return scores
+	}
+
+	return newCatalogFieldSorter(s.name, scores, cmp.Compare[float64], false)
+}
+
+func (s *LinearModelSorter) Name() string {
+	return s.name
+}
+
+func (s *LinearModelSorter) ID() string {
+	if s.id == "" {
+		return s.name
+	}
+	return s.id
+}
+
+func (s *LinearModelSorter) SetID(id string) {
+	s.id = id
+}
+
+func (s *LinearModelSorter) Direction() service.SortDirection {
+	return service.SortDescending
+}
+
+func (s *LinearModelSorter) Label(string) string {
+	return s.name
+}
+
+func (s *LinearModelSorter) Metadata() service.SorterMetadata {
+	description := s.description
+	if description == "" {
+		description = "Ranked by a model trained on past sales"
+		if version := s.Model().Version; version != "" {
+			description += " (version " + version + ")"
+		}
+	}
+
+	return service.SorterMetadata{
+		ID:          s.ID(),
+		Description: description,
+		Category:    CategoryCustom,
+		Direction:   s.Direction(),
+		Priority:    priorityRankingModel,
+	}
+}
+
+func (s *LinearModelSorter) SetDescription(description string) {
+	s.description = description
+}
+
+// Model returns the model currently in use.
+func (s *LinearModelSorter) Model() *LinearModel {
+	return s.model.Load()
+}
+
+func (s *LinearModelSorter) SetClock(clock Clock) {
+	s.clock = clock
+}
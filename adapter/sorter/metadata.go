@@ -0,0 +1,37 @@
+package sorter
+
+// Categories of the built-in sorters.
+const (
+	CategoryPrice      = "Price"
+	CategoryDate       = "Date"
+	CategoryName       = "Name"
+	CategoryPopularity = "Popularity"
+	CategoryCustom     = "Custom"
+	CategoryExperiment = "Experiment"
+)
+
+// Listing priorities of the built-in sorters, leaving room for third-party
+// sorters in between.
+const (
+	priorityPrice        = 10
+	priorityDate         = 20
+	priorityName         = 30
+	priorityNaturalName  = 31
+	priorityCollatedName = 32
+	prioritySalesPerView = 40
+	prioritySmoothed     = 41
+	priorityWilson       = 42
+	priorityTrending     = 50
+	priorityScore        = 60
+	priorityComposite    = 70
+	priorityRankingModel = 80
+	priorityShuffle      = 90
+	priorityJitter       = 91
+)
+
+func directionalDescription(ascending bool, ascendingText, descendingText string) string {
+	if ascending {
+		return ascendingText
+	}
+	return descendingText
+}
@@ -1,39 +1,24 @@
 package sorter
 
 import (
-	"sort"
 	"strings"
 
 	"assessment/domain/model"
 )
 
 type NameSorter struct {
-	ascending bool
+	*FieldSorter[string]
 }
 
 func NewNameSorter(ascending bool) *NameSorter {
-	return &NameSorter{
-		ascending: ascending,
+	name := "Name (descending)"
+	if ascending {
+		name = "Name (ascending)"
 	}
-}
-
-func (s *NameSorter) Sort(products model.ProductList) model.ProductList {
-
-	result := products.Clone()
 
-	sort.Slice(result, func(i, j int) bool {
-		if s.ascending {
-			return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
-		}
-		return strings.ToLower(result[i].Name) > strings.ToLower(result[j].Name)
-	})
-
-	return result
-}
-
-func (s *NameSorter) Name() string {
-	if s.ascending {
-		return "Name (ascending)"
+	return &NameSorter{
+		FieldSorter: NewFieldSorter(name, func(p *model.Product) string { return strings.ToLower(p.Name) }, ascending).
+			identify(sorterID("name", ascending), directionalLabel("Name", ascending)).
+			describe(CategoryName, priorityName, directionalDescription(ascending, "Alphabetical by name", "Reverse alphabetical by name")),
 	}
-	return "Name (descending)"
 }
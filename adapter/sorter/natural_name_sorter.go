@@ -0,0 +1,149 @@
+package sorter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"assessment/domain/model"
+)
+
+// NameNormalizer is one step of the pipeline applied to a product name
+// before natural comparison.
+type NameNormalizer func(name string) string
+
+var DefaultArticles = []string{"the", "a", "an"}
+
+func LowercaseNormalizer(name string) string {
+	return strings.ToLower(name)
+}
+
+func PunctuationNormalizer(name string) string {
+	return strings.Join(strings.Fields(strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return ' '
+		}
+		return r
+	}, name)), " ")
+}
+
+// ArticleNormalizer drops one leading article, so "The Table" sorts as "Table".
+func ArticleNormalizer(articles ...string) NameNormalizer {
+	return func(name string) string {
+		trimmed := strings.TrimSpace(name)
+		first, rest, found := strings.Cut(trimmed, " ")
+		if !found {
+			return trimmed
+		}
+		for _, article := range articles {
+			if strings.EqualFold(first, article) {
+				return strings.TrimSpace(rest)
+			}
+		}
+		return trimmed
+	}
+}
+
+// NameNormalizerByName resolves the normalizer names used in configuration:
+// "lowercase", "strip_punctuation" and "strip_articles".
+func NameNormalizerByName(name string, articles []string) (NameNormalizer, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "lowercase":
+		return LowercaseNormalizer, nil
+	case "strip_punctuation":
+		return PunctuationNormalizer, nil
+	case "strip_articles":
+		if len(articles) == 0 {
+			articles = DefaultArticles
+		}
+		return ArticleNormalizer(articles...), nil
+	default:
+		return nil, fmt.Errorf("unknown name normalizer: %s", name)
+	}
+}
+
+// NaturalNameSorter compares names with embedded digit runs ordered by their
+// numeric value, so "Table 2" comes before "Table 10".
+type NaturalNameSorter struct {
+	*FieldSorter[string]
+}
+
+func NewNaturalNameSorter(ascending bool, normalizers ...NameNormalizer) *NaturalNameSorter {
+	name := "Name (natural, descending)"
+	if ascending {
+		name = "Name (natural, ascending)"
+	}
+
+	normalize := func(p *model.Product) string {
+		key := p.Name
+		for _, normalizer := range normalizers {
+			key = normalizer(key)
+		}
+		return key
+	}
+
+	return &NaturalNameSorter{
+		FieldSorter: NewFieldSorterFunc(name, normalize, NaturalCompare, ascending).
+			identify(sorterID("natural_name", ascending), localizedLabel("%s (%s, %s)", "Name", "natural", directionLabel(ascending))).
+			describe(CategoryName, priorityNaturalName, directionalDescription(ascending,
+				"Alphabetical by name with numbers in numeric order", "Reverse alphabetical by name with numbers in numeric order")),
+	}
+}
+
+// NaturalCompare compares a and b rune by rune, except that runs of ASCII
+// digits are compared by numeric value. Equal numbers with different leading
+// zeros compare by the number of zeros so the order stays total.
+func NaturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			numA, restA := splitDigits(a)
+			numB, restB := splitDigits(b)
+
+			if c := compareDigitRuns(numA, numB); c != 0 {
+				return c
+			}
+
+			a, b = restA, restB
+			continue
+		}
+
+		runeA, sizeA := utf8.DecodeRuneInString(a)
+		runeB, sizeB := utf8.DecodeRuneInString(b)
+		if runeA != runeB {
+			if runeA < runeB {
+				return -1
+			}
+			return 1
+		}
+
+		a, b = a[sizeA:], b[sizeB:]
+	}
+
+	return len(a) - len(b)
+}
+
+func compareDigitRuns(a, b string) int {
+	trimmedA := strings.TrimLeft(a, "0")
+	trimmedB := strings.TrimLeft(b, "0")
+
+	if len(trimmedA) != len(trimmedB) {
+		return len(trimmedA) - len(trimmedB)
+	}
+	if c := strings.Compare(trimmedA, trimmedB); c != 0 {
+		return c
+	}
+	return len(a) - len(b)
+}
+
+func splitDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
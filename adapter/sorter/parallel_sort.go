@@ -0,0 +1,135 @@
+package sorter
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"assessment/domain/model"
+)
+
+const DefaultParallelThreshold = 50000
+
+var parallelThreshold atomic.Int64
+
+func init() {
+	parallelThreshold.Store(DefaultParallelThreshold)
+}
+
+// SetParallelThreshold sets the list size from which SortStable splits the
+// work across GOMAXPROCS workers. Smaller lists are sorted sequentially.
+func SetParallelThreshold(n int) {
+	parallelThreshold.Store(int64(n))
+}
+
+func ParallelThreshold() int {
+	return int(parallelThreshold.Load())
+}
+
+// SortStable stable-sorts products in place. Lists at or above the parallel
+// threshold are split into one chunk per worker, sorted concurrently and
+// merged, producing exactly the same order as a sequential stable sort.
+func SortStable(products model.ProductList, less func(a, b *model.Product) bool) {
+	sortStable(products, func(a, b *model.Product) int {
+		if less(a, b) {
+			return -1
+		}
+		if less(b, a) {
+			return 1
+		}
+		return 0
+	})
+}
+
+func sortStable[T any](items []T, compare func(a, b T) int) {
+	_ = sortStableContext(context.Background(), items, compare)
+}
+
+// sortStableContext is sortStable that stops between the parallel chunk sort
+// and each merge round once ctx is done, leaving items partly sorted.
+func sortStableContext[T any](ctx context.Context, items []T, compare func(a, b T) int) error {
+	workers := runtime.GOMAXPROCS(0)
+	if workers < 2 || len(items) < 2 || len(items) < ParallelThreshold() {
+		slices.SortStableFunc(items, compare)
+		return ctx.Err()
+	}
+
+	chunkSize := (len(items) + workers - 1) / workers
+
+	var bounds []int
+	for start := 0; start < len(items); start += chunkSize {
+		bounds = append(bounds, start)
+	}
+	bounds = append(bounds, len(items))
+
+	var wg sync.WaitGroup
+	for c := 0; c < len(bounds)-1; c++ {
+		chunk := items[bounds[c]:bounds[c+1]]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slices.SortStableFunc(chunk, compare)
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	src := items
+	dst := make([]T, len(items))
+
+	for len(bounds) > 2 {
+		next := make([]int, 0, len(bounds)/2+1)
+
+		for c := 0; c < len(bounds)-1; c += 2 {
+			lo := bounds[c]
+			next = append(next, lo)
+
+			if c+2 >= len(bounds) {
+				copy(dst[lo:], src[lo:bounds[c+1]])
+				continue
+			}
+
+			mid, hi := bounds[c+1], bounds[c+2]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mergeStable(dst[lo:hi], src[lo:mid], src[mid:hi], compare)
+			}()
+		}
+		wg.Wait()
+
+		bounds = append(next, len(items))
+		src, dst = dst, src
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	if &src[0] != &items[0] {
+		copy(items, src)
+	}
+	return nil
+}
+
+// mergeStable merges two sorted runs into dst, taking from left on ties.
+func mergeStable[T any](dst, left, right []T, compare func(a, b T) int) {
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if compare(right[j], left[i]) < 0 {
+			dst[k] = right[j]
+			j++
+		} else {
+			dst[k] = left[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], left[i:])
+	copy(dst[k:], right[j:])
+}
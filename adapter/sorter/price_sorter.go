@@ -1,38 +1,22 @@
 package sorter
 
 import (
-	"sort"
-
 	"assessment/domain/model"
 )
 
 type PriceSorter struct {
-	ascending bool
+	*FieldSorter[float64]
 }
 
 func NewPriceSorter(ascending bool) *PriceSorter {
-	return &PriceSorter{
-		ascending: ascending,
+	name := "Price (descending)"
+	if ascending {
+		name = "Price (ascending)"
 	}
-}
-
-func (s *PriceSorter) Sort(products model.ProductList) model.ProductList {
-
-	result := products.Clone()
 
-	sort.Slice(result, func(i, j int) bool {
-		if s.ascending {
-			return result[i].Price < result[j].Price
-		}
-		return result[i].Price > result[j].Price
-	})
-
-	return result
-}
-
-func (s *PriceSorter) Name() string {
-	if s.ascending {
-		return "Price (ascending)"
+	return &PriceSorter{
+		FieldSorter: NewFieldSorter(name, func(p *model.Product) float64 { return p.Price }, ascending).
+			identify(sorterID("price", ascending), directionalLabel("Price", ascending)).
+			describe(CategoryPrice, priorityPrice, directionalDescription(ascending, "Lowest price first", "Highest price first")),
 	}
-	return "Price (descending)"
 }
@@ -1,37 +1,26 @@
 package sorter
 
 import (
-	"sort"
-
 	"assessment/domain/model"
 )
 
 type SalesPerViewSorter struct {
-	ascending bool
+	*FieldSorter[float64]
 }
 
 func NewSalesPerViewSorter(ascending bool) *SalesPerViewSorter {
-	return &SalesPerViewSorter{
-		ascending: ascending,
+	name := "Sales per View (descending)"
+	if ascending {
+		name = "Sales per View (ascending)"
 	}
-}
-
-func (s *SalesPerViewSorter) Sort(products model.ProductList) model.ProductList {
-
-	result := products.Clone()
-
-	sort.Slice(result, func(i, j int) bool {
-
-		spv1 := calculateSalesPerView(result[i])
-		spv2 := calculateSalesPerView(result[j])
 
-		if s.ascending {
-			return spv1 < spv2
-		}
-		return spv1 > spv2
-	})
-
-	return result
+	return &SalesPerViewSorter{
+		FieldSorter: NewFieldSorter(name, calculateSalesPerView, ascending).
+			identify(sorterID("sales_per_view", ascending), directionalLabel("Sales per View", ascending),
+				sorterID("spv", ascending)).
+			describe(CategoryPopularity, prioritySalesPerView,
+				directionalDescription(ascending, "Lowest sales per view first", "Highest sales per view first")),
+	}
 }
 
 func calculateSalesPerView(p *model.Product) float64 {
@@ -40,10 +29,3 @@ func calculateSalesPerView(p *model.Product) float64 {
 	}
 	return float64(p.SalesCount) / float64(p.ViewsCount)
 }
-
-func (s *SalesPerViewSorter) Name() string {
-	if s.ascending {
-		return "Sales per View (ascending)"
-	}
-	return "Sales per View (descending)"
-}
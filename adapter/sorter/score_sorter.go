@@ -0,0 +1,215 @@
+package sorter
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+type Normalization string
+
+const (
+	NormalizeNone   Normalization = "none"
+	NormalizeMinMax Normalization = "min_max"
+	NormalizeZScore Normalization = "z_score"
+	NormalizeLog    Normalization = "log"
+)
+
+type signalFunc func(p *model.Product, now time.Time) float64
+
+var scoreSignals = map[string]signalFunc{
+	"price": func(p *model.Product, _ time.Time) float64 { return p.Price },
+	"age": func(p *model.Product, now time.Time) float64 {
+		return now.Sub(p.Created).Hours() / 24
+	},
+	"sales_per_view": func(p *model.Product, _ time.Time) float64 { return calculateSalesPerView(p) },
+	"sales":          func(p *model.Product, _ time.Time) float64 { return float64(p.SalesCount) },
+	"views":          func(p *model.Product, _ time.Time) float64 { return float64(p.ViewsCount) },
+}
+
+type ScoreComponent struct {
+	Signal        string
+	Weight        float64
+	Normalization Normalization
+}
+
+type ScoreSorter struct {
+	*FieldSorter[float64]
+	components []ScoreComponent
+	signals    []signalFunc
+	clock      Clock
+}
+
+func NewScoreSorter(name string, ascending bool, components ...ScoreComponent) (*ScoreSorter, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("score sorter requires a name")
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("score sorter %q requires at least one component", name)
+	}
+
+	s := &ScoreSorter{
+		components: make([]ScoreComponent, 0, len(components)),
+		signals:    make([]signalFunc, 0, len(components)),
+		clock:      SystemClock,
+	}
+
+	for _, c := range components {
+		signal := strings.ToLower(strings.TrimSpace(c.Signal))
+		extract, ok := scoreSignals[signal]
+		if !ok {
+			return nil, fmt.Errorf("unknown score signal: %s", c.Signal)
+		}
+
+		normalization := Normalization(strings.ToLower(string(c.Normalization)))
+		switch normalization {
+		case "":
+			normalization = NormalizeNone
+		case NormalizeNone, NormalizeMinMax, NormalizeZScore, NormalizeLog:
+		default:
+			return nil, fmt.Errorf("unknown normalization %q for signal %s", c.Normalization, c.Signal)
+		}
+
+		s.components = append(s.components, ScoreComponent{Signal: signal, Weight: c.Weight, Normalization: normalization})
+		s.signals = append(s.signals, extract)
+	}
+
+	s.FieldSorter = newCatalogFieldSorter(name, s.scores, cmp.Compare[float64], ascending).
+		identify(slugID(name), nil).
+		describe(CategoryCustom, priorityScore, "Weighted score of "+strings.Join(signalNames(components), ", "))
+	return s, nil
+}
+
+func signalNames(components []ScoreComponent) []string {
+	names := make([]string, len(components))
+	for i, c := range components {
+		names[i] = strings.ToLower(strings.TrimSpace(c.Signal))
+	}
+	return names
+}
+
+func (s *ScoreSorter) scores(products model.ProductList) []float64 {
+	contributions := s.contributions(products)
+
+	scores := make([]float64, len(products))
+	for i := range products {
+		for _, c := range contributions[i] {
+			scores[i] += c
+		}
+	}
+	return scores
+}
+
+// contributions returns, for each product, the weighted normalized value of
+// every component in the order they were declared.
+func (s *ScoreSorter) contributions(products model.ProductList) [][]float64 {
+	now := s.clock.Now()
+
+	result := make([][]float64, len(products))
+	for i := range result {
+		result[i] = make([]float64, len(s.components))
+	}
+
+	values := make([]float64, len(products))
+	for c, component := range s.components {
+		for i, p := range products {
+			values[i] = s.signals[c](p, now)
+		}
+
+		normalize(values, component.Normalization)
+
+		for i := range products {
+			result[i][c] = component.Weight * values[i]
+		}
+	}
+
+	return result
+}
+
+// Explain reports each product's score broken down into the weighted
+// contribution of every component.
+func (s *ScoreSorter) Explain(products model.ProductList) []service.RankExplanation {
+	contributions := s.contributions(products)
+
+	scores := make([]float64, len(products))
+	for i := range products {
+		for _, c := range contributions[i] {
+			scores[i] += c
+		}
+	}
+
+	fixed := newCatalogFieldSorter(s.Name(), func(model.ProductList) []float64 { return scores }, cmp.Compare[float64], s.Ascending())
+	return fixed.explain(products, func(i int) []service.ExplanationComponent {
+		components := make([]service.ExplanationComponent, len(s.components))
+		for c, component := range s.components {
+			components[c] = service.ExplanationComponent{
+				Name:  fmt.Sprintf("%s (%s, weight %g)", component.Signal, component.Normalization, component.Weight),
+				Value: contributions[i][c],
+			}
+		}
+		return components
+	})
+}
+
+func normalize(values []float64, normalization Normalization) {
+	if len(values) == 0 {
+		return
+	}
+
+	switch normalization {
+	case NormalizeMinMax:
+		lo, hi := values[0], values[0]
+		for _, v := range values {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+		for i, v := range values {
+			if hi == lo {
+				values[i] = 0
+			} else {
+				values[i] = (v - lo) / (hi - lo)
+			}
+		}
+
+	case NormalizeZScore:
+		var mean float64
+		for _, v := range values {
+			mean += v
+		}
+		mean /= float64(len(values))
+
+		var variance float64
+		for _, v := range values {
+			variance += (v - mean) * (v - mean)
+		}
+		stddev := math.Sqrt(variance / float64(len(values)))
+
+		for i, v := range values {
+			if stddev == 0 {
+				values[i] = 0
+			} else {
+				values[i] = (v - mean) / stddev
+			}
+		}
+
+	case NormalizeLog:
+		for i, v := range values {
+			values[i] = math.Log1p(math.Max(v, 0))
+		}
+	}
+}
+
+func (s *ScoreSorter) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+func (s *ScoreSorter) Components() []ScoreComponent {
+	components := make([]ScoreComponent, len(s.components))
+	copy(components, s.components)
+	return components
+}
@@ -0,0 +1,117 @@
+package sorter
+
+import (
+	"cmp"
+	"context"
+	"hash/fnv"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+type shuffleSeedKey struct{}
+
+// WithShuffleSeed returns a context that makes shuffle and jitter sorters use
+// seed instead of their configured seed, typically one derived from a user or
+// session with SeedFromString.
+func WithShuffleSeed(ctx context.Context, seed int64) context.Context {
+	return context.WithValue(ctx, shuffleSeedKey{}, seed)
+}
+
+func shuffleSeedFromContext(ctx context.Context, fallback int64) int64 {
+	if seed, ok := ctx.Value(shuffleSeedKey{}).(int64); ok {
+		return seed
+	}
+	return fallback
+}
+
+// SeedFromString derives a shuffle seed from an identifier such as a user or
+// session ID.
+func SeedFromString(s string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return int64(h.Sum64())
+}
+
+// ShuffleSorter orders products by a pseudo-random key derived from the seed
+// and the product ID, so a seed always yields the same permutation and a
+// product's place relative to the others survives catalog changes.
+type ShuffleSorter struct {
+	seed int64
+}
+
+func NewShuffleSorter(seed int64) *ShuffleSorter {
+	return &ShuffleSorter{seed: seed}
+}
+
+func (s *ShuffleSorter) Sort(products model.ProductList) model.ProductList {
+	return shuffle(products, s.seed)
+}
+
+func (s *ShuffleSorter) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return shuffle(products, shuffleSeedFromContext(ctx, s.seed)), nil
+}
+
+func (s *ShuffleSorter) BreaksTiesByID() bool {
+	return true
+}
+
+func (s *ShuffleSorter) Explain(products model.ProductList) []service.RankExplanation {
+	return shuffleSorter(s.seed).Explain(products)
+}
+
+func (s *ShuffleSorter) Name() string {
+	return "Shuffle"
+}
+
+func (s *ShuffleSorter) ID() string {
+	return "shuffle"
+}
+
+func (s *ShuffleSorter) Direction() service.SortDirection {
+	return ""
+}
+
+func (s *ShuffleSorter) Label(locale string) string {
+	return translate(locale, "Shuffle")
+}
+
+func (s *ShuffleSorter) Metadata() service.SorterMetadata {
+	return service.SorterMetadata{
+		ID:          s.ID(),
+		Description: "Random order that stays the same for each visitor",
+		Category:    CategoryExperiment,
+		Priority:    priorityShuffle,
+	}
+}
+
+func (s *ShuffleSorter) Seed() int64 {
+	return s.seed
+}
+
+func shuffle(products model.ProductList, seed int64) model.ProductList {
+	return shuffleSorter(seed).Sort(products)
+}
+
+func shuffleSorter(seed int64) *FieldSorter[uint64] {
+	keys := func(products model.ProductList) []uint64 {
+		keys := make([]uint64, len(products))
+		for i, p := range products {
+			keys[i] = shuffleKey(seed, p.ID)
+		}
+		return keys
+	}
+
+	return newCatalogFieldSorter("Shuffle", keys, cmp.Compare[uint64], true)
+}
+
+// shuffleKey mixes seed and id with the SplitMix64 finalizer.
+func shuffleKey(seed int64, id int) uint64 {
+	z := uint64(seed) + uint64(id)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
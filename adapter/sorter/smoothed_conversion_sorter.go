@@ -0,0 +1,75 @@
+package sorter
+
+import (
+	"cmp"
+
+	"assessment/domain/model"
+)
+
+// ConversionPrior is the pseudo-count added to every product's sales and views.
+// With UseCatalogAverage set, Sales is ignored and derived from the catalog-wide
+// conversion rate so that the prior carries Views worth of average traffic.
+type ConversionPrior struct {
+	Sales             float64
+	Views             float64
+	UseCatalogAverage bool
+}
+
+type SmoothedConversionSorter struct {
+	*FieldSorter[float64]
+	prior ConversionPrior
+}
+
+func NewSmoothedConversionSorter(ascending bool, prior ConversionPrior) *SmoothedConversionSorter {
+	name := "Smoothed Conversion (descending)"
+	if ascending {
+		name = "Smoothed Conversion (ascending)"
+	}
+
+	s := &SmoothedConversionSorter{prior: prior}
+	s.FieldSorter = newCatalogFieldSorter(name, s.scores, cmp.Compare[float64], ascending).
+		identify(sorterID("smoothed_conversion", ascending), directionalLabel("Smoothed Conversion", ascending)).
+		describe(CategoryPopularity, prioritySmoothed, "Sales per view smoothed so low-traffic products don't dominate")
+	return s
+}
+
+func (s *SmoothedConversionSorter) scores(products model.ProductList) []float64 {
+	priorSales, priorViews := s.resolvePrior(products)
+
+	scores := make([]float64, len(products))
+	for i, p := range products {
+		scores[i] = calculateSmoothedConversion(p, priorSales, priorViews)
+	}
+	return scores
+}
+
+func (s *SmoothedConversionSorter) resolvePrior(products model.ProductList) (float64, float64) {
+	if !s.prior.UseCatalogAverage {
+		return s.prior.Sales, s.prior.Views
+	}
+
+	var totalSales, totalViews int
+	for _, p := range products {
+		totalSales += p.SalesCount
+		totalViews += p.ViewsCount
+	}
+
+	if totalViews == 0 {
+		return 0, s.prior.Views
+	}
+
+	averageRate := float64(totalSales) / float64(totalViews)
+	return averageRate * s.prior.Views, s.prior.Views
+}
+
+func calculateSmoothedConversion(p *model.Product, priorSales, priorViews float64) float64 {
+	views := float64(p.ViewsCount) + priorViews
+	if views <= 0 {
+		return 0
+	}
+	return (float64(p.SalesCount) + priorSales) / views
+}
+
+func (s *SmoothedConversionSorter) Prior() ConversionPrior {
+	return s.prior
+}
@@ -0,0 +1,86 @@
+package sorter
+
+import (
+	"fmt"
+	"strings"
+
+	"assessment/domain/service"
+)
+
+type ExpressionError struct {
+	Expression string
+	Token      string
+	Position   int
+	Reason     string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("invalid sort expression %q: %s at position %d: %q",
+		e.Expression, e.Reason, e.Position, e.Token)
+}
+
+type ExpressionParser struct{}
+
+func NewExpressionParser() *ExpressionParser {
+	return &ExpressionParser{}
+}
+
+func (p *ExpressionParser) Parse(expression string) (service.Sorter, error) {
+	return ParseSortExpression(expression)
+}
+
+// ParseSortExpression builds a CompositeSorter from a comma separated list of
+// "field[:direction]" terms such as "price:asc,created:desc,name".
+// Direction defaults to ascending.
+func ParseSortExpression(expression string) (*CompositeSorter, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, &ExpressionError{Expression: expression, Reason: "empty expression"}
+	}
+
+	var keys []SortKey
+	seen := make(map[string]bool)
+
+	offset := 0
+	for _, term := range strings.Split(expression, ",") {
+		position := offset + len(term) - len(strings.TrimLeft(term, " \t"))
+		offset += len(term) + 1
+
+		token := strings.TrimSpace(term)
+		if token == "" {
+			return nil, &ExpressionError{Expression: expression, Token: term, Position: position, Reason: "empty sort term"}
+		}
+
+		field, direction, hasDirection := strings.Cut(token, ":")
+
+		canonical, _, ok := lookupSortField(field)
+		if !ok {
+			return nil, &ExpressionError{Expression: expression, Token: field, Position: position, Reason: "unknown field"}
+		}
+
+		if seen[canonical] {
+			return nil, &ExpressionError{Expression: expression, Token: field, Position: position, Reason: "duplicate field"}
+		}
+		seen[canonical] = true
+
+		ascending := true
+		if hasDirection {
+			switch strings.ToLower(strings.TrimSpace(direction)) {
+			case "asc", "ascending":
+				ascending = true
+			case "desc", "descending":
+				ascending = false
+			default:
+				return nil, &ExpressionError{
+					Expression: expression,
+					Token:      direction,
+					Position:   position + len(field) + 1,
+					Reason:     "unknown direction",
+				}
+			}
+		}
+
+		keys = append(keys, SortKey{Field: canonical, Ascending: ascending})
+	}
+
+	return NewCompositeSorter("", keys...)
+}
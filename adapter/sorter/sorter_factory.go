@@ -0,0 +1,253 @@
+package sorter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+	"assessment/infrastructure/config"
+)
+
+// SorterFactory builds a sorter of one type from its config, decoding the
+// settings of that type from c.Params. The ID and description in c are applied
+// by the SorterFactoryRegistry afterwards.
+type SorterFactory func(c config.SorterConfig) (service.Sorter, error)
+
+// SorterFactoryRegistry holds the sorter types that config can declare
+// sorters of.
+type SorterFactoryRegistry struct {
+	factories map[string]SorterFactory
+	mutex     sync.RWMutex
+}
+
+func NewSorterFactoryRegistry() *SorterFactoryRegistry {
+	return &SorterFactoryRegistry{
+		factories: make(map[string]SorterFactory),
+	}
+}
+
+// DefaultSorterFactories returns a registry of the built-in sorter types:
+// "field", "score", "wilson" and "composite".
+func DefaultSorterFactories() *SorterFactoryRegistry {
+	r := NewSorterFactoryRegistry()
+	r.factories["field"] = newFieldSorterFromConfig
+	r.factories["score"] = newScoreSorterFromConfig
+	r.factories["wilson"] = newWilsonScoreSorterFromConfig
+	r.factories["composite"] = newCompositeSorterFromConfig
+	return r
+}
+
+// Register adds a sorter type. Types are case-insensitive and can only be
+// registered once.
+func (r *SorterFactoryRegistry) Register(sorterType string, factory SorterFactory) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := strings.ToLower(strings.TrimSpace(sorterType))
+	if key == "" {
+		return fmt.Errorf("sorter type is empty")
+	}
+	if _, exists := r.factories[key]; exists {
+		return fmt.Errorf("sorter type already registered: %s", key)
+	}
+
+	r.factories[key] = factory
+	return nil
+}
+
+// Types returns the registered sorter types in alphabetical order.
+func (r *SorterFactoryRegistry) Types() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	types := make([]string, 0, len(r.factories))
+	for t := range r.factories {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// Build builds the sorter c declares with the factory of its type.
+func (r *SorterFactoryRegistry) Build(c config.SorterConfig) (service.Sorter, error) {
+	r.mutex.RLock()
+	factory, exists := r.factories[strings.ToLower(strings.TrimSpace(c.Type))]
+	r.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unknown sorter type: %q", c.Type)
+	}
+
+	sorter, err := factory(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID != "" {
+		identifiable, ok := sorter.(interface{ SetID(id string) })
+		if !ok {
+			return nil, fmt.Errorf("sorter type %s does not support setting an ID", c.Type)
+		}
+		identifiable.SetID(c.ID)
+	}
+
+	if c.Description != "" {
+		describable, ok := sorter.(interface{ SetDescription(description string) })
+		if !ok {
+			return nil, fmt.Errorf("sorter type %s does not support setting a description", c.Type)
+		}
+		describable.SetDescription(c.Description)
+	}
+
+	return sorter, nil
+}
+
+// decodeParams decodes the params of c into params, rejecting unknown fields
+// so that typos in config are reported rather than ignored.
+func decodeParams(c config.SorterConfig, params any) error {
+	if len(c.Params) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(c.Params))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(params); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
+
+type fieldSorterParams struct {
+	Field     string `json:"field"`
+	Ascending bool   `json:"ascending"`
+}
+
+// newFieldSorterFromConfig builds the FieldSorter of a field: the built-in
+// sorter of that field and direction, renamed when c has a name.
+func newFieldSorterFromConfig(c config.SorterConfig) (service.Sorter, error) {
+	var params fieldSorterParams
+	if err := decodeParams(c, &params); err != nil {
+		return nil, err
+	}
+	if params.Field == "" {
+		return nil, fmt.Errorf("field sorter requires a field")
+	}
+
+	sorter, err := newFieldSorter(params.Field, params.Ascending)
+	if err != nil {
+		return nil, err
+	}
+	if c.Name != "" {
+		sorter.rename(c.Name)
+	}
+	return sorter, nil
+}
+
+type renamableSorter interface {
+	service.Sorter
+	rename(name string)
+}
+
+// newFieldSorter returns the sorter of field in the given direction. Fields
+// without a built-in sorter get a FieldSorter identified like a sort
+// expression term.
+func newFieldSorter(field string, ascending bool) (renamableSorter, error) {
+	canonical, f, ok := lookupSortField(field)
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field: %s", field)
+	}
+
+	var key func(p *model.Product) int
+	category := CategoryPopularity
+	switch canonical {
+	case "price":
+		return NewPriceSorter(ascending), nil
+	case "created":
+		return NewDateSorter(ascending), nil
+	case "name":
+		return NewNameSorter(ascending), nil
+	case "sales_per_view":
+		return NewSalesPerViewSorter(ascending), nil
+	case "sales":
+		key = func(p *model.Product) int { return p.SalesCount }
+	case "views":
+		key = func(p *model.Product) int { return p.ViewsCount }
+	case "id":
+		key = func(p *model.Product) int { return p.ID }
+		category = CategoryCustom
+	default:
+		return nil, fmt.Errorf("sort field has no sorter: %s", field)
+	}
+
+	label := directionalLabel(f.label, ascending)
+	return NewFieldSorter(label(""), key, ascending).
+		identify(sorterID(canonical, ascending), label).
+		describe(category, priorityComposite, ""), nil
+}
+
+type scoreSorterParams struct {
+	Ascending  bool                          `json:"ascending"`
+	Components []config.ScoreComponentConfig `json:"components"`
+}
+
+func newScoreSorterFromConfig(c config.SorterConfig) (service.Sorter, error) {
+	var params scoreSorterParams
+	if err := decodeParams(c, &params); err != nil {
+		return nil, err
+	}
+
+	components := make([]ScoreComponent, 0, len(params.Components))
+	for _, component := range params.Components {
+		components = append(components, ScoreComponent{
+			Signal:        component.Signal,
+			Weight:        component.Weight,
+			Normalization: Normalization(component.Normalization),
+		})
+	}
+
+	return NewScoreSorter(c.Name, params.Ascending, components...)
+}
+
+type wilsonScoreSorterParams struct {
+	Ascending       bool    `json:"ascending"`
+	ConfidenceLevel float64 `json:"confidence_level"`
+}
+
+func newWilsonScoreSorterFromConfig(c config.SorterConfig) (service.Sorter, error) {
+	params := wilsonScoreSorterParams{ConfidenceLevel: 0.95}
+	if err := decodeParams(c, &params); err != nil {
+		return nil, err
+	}
+
+	wilson, err := NewWilsonScoreSorter(params.Ascending, params.ConfidenceLevel)
+	if err != nil {
+		return nil, err
+	}
+	if c.Name != "" {
+		wilson.rename(c.Name)
+	}
+	return wilson, nil
+}
+
+type compositeSorterParams struct {
+	Keys []config.SortKeyConfig `json:"keys"`
+}
+
+func newCompositeSorterFromConfig(c config.SorterConfig) (service.Sorter, error) {
+	var params compositeSorterParams
+	if err := decodeParams(c, &params); err != nil {
+		return nil, err
+	}
+
+	keys := make([]SortKey, 0, len(params.Keys))
+	for _, k := range params.Keys {
+		keys = append(keys, SortKey{Field: k.Field, Ascending: k.Ascending})
+	}
+
+	return NewCompositeSorter(c.Name, keys...)
+}
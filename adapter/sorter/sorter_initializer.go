@@ -1,21 +1,359 @@
 package sorter
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
 	"assessment/domain/service"
 	"assessment/infrastructure/config"
 )
 
-func InitializeDefaultSorters(registry service.SorterRegistry, cfg *config.Config) {
+// InitializeDefaultSorters registers the built-in sorters and those defined in
+// cfg. Sorters defined in cfg are registered with ConflictReject, so one that
+// clashes with a built-in sorter or another configured sorter is an error.
+func InitializeDefaultSorters(registry service.SorterRegistry, cfg *config.Config) error {
+	return InitializeSorters(registry, cfg, DefaultSorterFactories())
+}
+
+// InitializeSorters is InitializeDefaultSorters with the sorter types taken
+// from factories, which must include the default ones. The built-in field and
+// Wilson score sorters, score_profiles, composite_sorters and the "sorters"
+// array of cfg are all built by the factories.
+func InitializeSorters(registry service.SorterRegistry, cfg *config.Config, factories *SorterFactoryRegistry) error {
+
+	if cfg == nil {
+		cfg = config.NewConfig()
+	}
+	if factories == nil {
+		factories = DefaultSorterFactories()
+	}
+
+	fields, err := fieldSorterConfigs("price", "created", "name")
+	if err != nil {
+		return err
+	}
+	if err := registerBuiltinSorters(registry, factories, fields); err != nil {
+		return err
+	}
+
+	if err := registerCollatedNameSorters(registry, cfg.NameLocales); err != nil {
+		return err
+	}
+
+	if err := registerNaturalNameSorters(registry, cfg.NaturalNameSort); err != nil {
+		return err
+	}
+
+	popularity, err := fieldSorterConfigs("sales_per_view")
+	if err != nil {
+		return err
+	}
+	if err := registerBuiltinSorters(registry, factories, popularity); err != nil {
+		return err
+	}
+
+	prior := conversionPriorFromConfig(cfg.SmoothedConversion)
+	registry.RegisterSorter(NewSmoothedConversionSorter(true, prior))
+	registry.RegisterSorter(NewSmoothedConversionSorter(false, prior))
+
+	wilson, err := wilsonScoreSorterConfigs(cfg.WilsonScore)
+	if err != nil {
+		return err
+	}
+	if err := registerBuiltinSorters(registry, factories, wilson); err != nil {
+		return err
+	}
+
+	halfLife := time.Duration(cfg.Trending.HalfLifeDays * float64(24*time.Hour))
+	trending, err := NewTrendingSorter(halfLife, SystemClock)
+	if err != nil {
+		return fmt.Errorf("invalid trending sorter: %w", err)
+	}
+	registry.RegisterSorter(trending)
+
+	profiles, err := scoreProfileSorterConfigs(cfg.ScoreProfiles)
+	if err != nil {
+		return err
+	}
+	if err := registerConfiguredSorters(registry, factories, "score profile", profiles); err != nil {
+		return err
+	}
+
+	composites, err := compositeSorterConfigs(cfg.CompositeSorters)
+	if err != nil {
+		return err
+	}
+	if err := registerConfiguredSorters(registry, factories, "composite sorter", composites); err != nil {
+		return err
+	}
+
+	if err := registerRankingModels(registry, cfg.RankingModels); err != nil {
+		return err
+	}
+
+	if err := registerConfiguredSorters(registry, factories, "sorter", cfg.Sorters); err != nil {
+		return err
+	}
+
+	registry.RegisterSorter(NewShuffleSorter(cfg.Shuffle.Seed))
+
+	if err := registerJitterSorters(registry, cfg.Shuffle); err != nil {
+		return err
+	}
+
+	if ordered, ok := registry.(service.OrderedSorterRegistry); ok && len(cfg.SorterOrder) > 0 {
+		ordered.SetOrder(cfg.SorterOrder)
+	}
+
+	return nil
+}
+
+// InitializeTenantSorters is InitializeSorters for the global registry of
+// registries, followed by the sorters and listing order of each tenant in
+// cfg.Tenants, which are registered in that tenant's namespace.
+func InitializeTenantSorters(registries service.TenantSorterRegistry, cfg *config.Config, factories *SorterFactoryRegistry) error {
+
+	if cfg == nil {
+		cfg = config.NewConfig()
+	}
+
+	if err := InitializeSorters(registries.Global(), cfg, factories); err != nil {
+		return err
+	}
+
+	tenantIDs := slices.Sorted(maps.Keys(cfg.Tenants))
+	for _, tenantID := range tenantIDs {
+		t := cfg.Tenants[tenantID]
+		registry := registries.AddTenant(tenantID)
+
+		if err := registerConfiguredSorters(registry, factories, "sorter", t.Sorters); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenantID, err)
+		}
+
+		if ordered, ok := registry.(service.OrderedSorterRegistry); ok && len(t.SorterOrder) > 0 {
+			ordered.SetOrder(t.SorterOrder)
+		}
+	}
+
+	return nil
+}
+
+// registerJitterSorters runs last so that jitter can be applied to any sorter
+// registered before it.
+func registerJitterSorters(registry service.SorterRegistry, c config.ShuffleConfig) error {
+	for _, j := range c.Jitter {
+		base, exists := registry.GetSorter(j.Sorter)
+		if !exists {
+			return fmt.Errorf("invalid jitter sorter: sorter not found: %s", j.Sorter)
+		}
+
+		jitter, err := NewJitterSorter(base, j.Window, c.Seed)
+		if err != nil {
+			return fmt.Errorf("invalid jitter sorter: %w", err)
+		}
+
+		if err := service.Register(registry, jitter, service.ConflictReject); err != nil {
+			return fmt.Errorf("invalid jitter sorter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// registerConfiguredSorters builds the sorters of a config section with
+// factories and registers them with ConflictReject.
+func registerConfiguredSorters(registry service.SorterRegistry, factories *SorterFactoryRegistry, section string, configs []config.SorterConfig) error {
+	for i, c := range configs {
+		sorter, err := factories.Build(c)
+		if err != nil {
+			return fmt.Errorf("invalid %s %d (%s): %w", section, i, c.Type, err)
+		}
+
+		if err := service.Register(registry, sorter, service.ConflictReject); err != nil {
+			return fmt.Errorf("invalid %s %d (%s): %w", section, i, c.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// registerBuiltinSorters builds built-in sorters with factories and registers
+// them with ConflictReplace, as built-in sorters are registered again when a
+// registry is initialized twice.
+func registerBuiltinSorters(registry service.SorterRegistry, factories *SorterFactoryRegistry, configs []config.SorterConfig) error {
+	for _, c := range configs {
+		sorter, err := factories.Build(c)
+		if err != nil {
+			return fmt.Errorf("invalid built-in %s sorter: %w", c.Type, err)
+		}
+
+		registry.RegisterSorter(sorter)
+	}
+
+	return nil
+}
+
+// fieldSorterConfigs declares the ascending and descending sorter of each
+// field.
+func fieldSorterConfigs(fields ...string) ([]config.SorterConfig, error) {
+	configs := make([]config.SorterConfig, 0, 2*len(fields))
+	for _, field := range fields {
+		for _, ascending := range []bool{true, false} {
+			params, err := json.Marshal(fieldSorterParams{Field: field, Ascending: ascending})
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s sorter: %w", field, err)
+			}
+			configs = append(configs, config.SorterConfig{Type: "field", Params: params})
+		}
+	}
+	return configs, nil
+}
+
+func wilsonScoreSorterConfigs(c config.WilsonScoreConfig) ([]config.SorterConfig, error) {
+	configs := make([]config.SorterConfig, 0, 2)
+	for _, ascending := range []bool{true, false} {
+		params, err := json.Marshal(wilsonScoreSorterParams{Ascending: ascending, ConfidenceLevel: c.ConfidenceLevel})
+		if err != nil {
+			return nil, fmt.Errorf("invalid wilson score sorter: %w", err)
+		}
+		configs = append(configs, config.SorterConfig{Type: "wilson", Params: params})
+	}
+	return configs, nil
+}
+
+// scoreProfileSorterConfigs declares the sorters of score_profiles as "score"
+// sorters.
+func scoreProfileSorterConfigs(profiles []config.ScoreProfileConfig) ([]config.SorterConfig, error) {
+	configs := make([]config.SorterConfig, 0, len(profiles))
+	for _, profile := range profiles {
+		params, err := json.Marshal(scoreSorterParams{Ascending: profile.Ascending, Components: profile.Components})
+		if err != nil {
+			return nil, fmt.Errorf("invalid score profile %q: %w", profile.Name, err)
+		}
+		configs = append(configs, config.SorterConfig{
+			Type:        "score",
+			ID:          profile.ID,
+			Name:        profile.Name,
+			Description: profile.Description,
+			Params:      params,
+		})
+	}
+	return configs, nil
+}
+
+// compositeSorterConfigs declares the sorters of composite_sorters as
+// "composite" sorters.
+func compositeSorterConfigs(composites []config.CompositeSorterConfig) ([]config.SorterConfig, error) {
+	configs := make([]config.SorterConfig, 0, len(composites))
+	for _, c := range composites {
+		params, err := json.Marshal(compositeSorterParams{Keys: c.Keys})
+		if err != nil {
+			return nil, fmt.Errorf("invalid composite sorter %q: %w", c.Name, err)
+		}
+		configs = append(configs, config.SorterConfig{
+			Type:        "composite",
+			ID:          c.ID,
+			Name:        c.Name,
+			Description: c.Description,
+			Params:      params,
+		})
+	}
+	return configs, nil
+}
+
+func registerRankingModels(registry service.SorterRegistry, configs []config.RankingModelConfig) error {
+	for _, c := range configs {
+		ranking, err := NewLinearModelSorterFromFile(c.Name, c.ModelFile)
+		if err != nil {
+			return fmt.Errorf("invalid ranking model %q: %w", c.Name, err)
+		}
+		if c.ID != "" {
+			ranking.SetID(c.ID)
+		}
+		if c.Description != "" {
+			ranking.SetDescription(c.Description)
+		}
+
+		if err := service.Register(registry, ranking, service.ConflictReject); err != nil {
+			return fmt.Errorf("invalid ranking model %q: %w", c.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ReloadRankingModels reloads the model file of every ranking model in cfg
+// that is registered in registry. A model that fails to load keeps its
+// current one; the errors are joined. Each sorter reads the file it was
+// registered with, so pointing model_file at another file needs a restart.
+func ReloadRankingModels(registry service.SorterRegistry, cfg *config.Config) error {
+	var errs []error
+	for _, c := range cfg.RankingModels {
+		id := c.ID
+		if id == "" {
+			id = slugID(c.Name)
+		}
+
+		registered, exists := registry.GetSorter(id)
+		if !exists {
+			continue
+		}
+		ranking, ok := service.Unwrap(registered).(*LinearModelSorter)
+		if !ok {
+			continue
+		}
+
+		if err := ranking.Reload(); err != nil {
+			errs = append(errs, fmt.Errorf("ranking model %q: %w", c.Name, err))
+		}
+	}
 
-	registry.RegisterSorter(NewPriceSorter(true))
-	registry.RegisterSorter(NewPriceSorter(false))
+	return errors.Join(errs...)
+}
+
+func registerCollatedNameSorters(registry service.SorterRegistry, locales []string) error {
+	for _, locale := range locales {
+		for _, ascending := range []bool{true, false} {
+			collated, err := NewCollatedNameSorter(ascending, locale)
+			if err != nil {
+				return fmt.Errorf("invalid name sorter: %w", err)
+			}
+
+			registry.RegisterSorter(collated)
+		}
+	}
+
+	return nil
+}
+
+func registerNaturalNameSorters(registry service.SorterRegistry, c config.NaturalNameSortConfig) error {
+	if !c.Enabled {
+		return nil
+	}
 
-	registry.RegisterSorter(NewDateSorter(true))
-	registry.RegisterSorter(NewDateSorter(false))
+	normalizers := make([]NameNormalizer, 0, len(c.Normalizers))
+	for _, name := range c.Normalizers {
+		normalizer, err := NameNormalizerByName(name, c.Articles)
+		if err != nil {
+			return fmt.Errorf("invalid natural name sorter: %w", err)
+		}
+		normalizers = append(normalizers, normalizer)
+	}
 
-	registry.RegisterSorter(NewNameSorter(true))
-	registry.RegisterSorter(NewNameSorter(false))
+	registry.RegisterSorter(NewNaturalNameSorter(true, normalizers...))
+	registry.RegisterSorter(NewNaturalNameSorter(false, normalizers...))
+
+	return nil
+}
 
-	registry.RegisterSorter(NewSalesPerViewSorter(true))
-	registry.RegisterSorter(NewSalesPerViewSorter(false))
+func conversionPriorFromConfig(c config.SmoothedConversionConfig) ConversionPrior {
+	return ConversionPrior{
+		Sales:             c.PriorSales,
+		Views:             c.PriorViews,
+		UseCatalogAverage: c.UseCatalogAverage,
+	}
 }
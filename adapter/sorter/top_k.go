@@ -0,0 +1,54 @@
+package sorter
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// selectTopK returns the first k items in compare order without sorting the
+// rest of the list. It runs in O(n log k) and leaves items untouched.
+func selectTopK[T any](items []T, k int, compare func(a, b T) int) []T {
+	if k <= 0 {
+		return []T{}
+	}
+	if k >= len(items) {
+		all := slices.Clone(items)
+		sortStable(all, compare)
+		return all
+	}
+
+	// The heap keeps the current best k with the worst of them on top.
+	h := &boundedHeap[T]{items: make([]T, 0, k), compare: compare}
+	for _, item := range items {
+		if h.Len() < k {
+			heap.Push(h, item)
+		} else if compare(item, h.items[0]) < 0 {
+			h.items[0] = item
+			heap.Fix(h, 0)
+		}
+	}
+
+	top := h.items
+	slices.SortStableFunc(top, compare)
+
+	return top
+}
+
+type boundedHeap[T any] struct {
+	items   []T
+	compare func(a, b T) int
+}
+
+func (h *boundedHeap[T]) Len() int { return len(h.items) }
+
+func (h *boundedHeap[T]) Less(i, j int) bool { return h.compare(h.items[j], h.items[i]) < 0 }
+
+func (h *boundedHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *boundedHeap[T]) Push(x any) { h.items = append(h.items, x.(T)) }
+
+func (h *boundedHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
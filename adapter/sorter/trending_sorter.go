@@ -0,0 +1,67 @@
+package sorter
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"time"
+
+	"assessment/domain/model"
+)
+
+const DefaultTrendingHalfLife = 30 * 24 * time.Hour
+
+// TrendingSorter ranks products by sales velocity (sales per day since the
+// product was created) decayed by its age, halving every half-life. A new
+// product selling well rises quickly while an old bestseller slowly falls.
+type TrendingSorter struct {
+	*FieldSorter[float64]
+	halfLife time.Duration
+	clock    Clock
+}
+
+func NewTrendingSorter(halfLife time.Duration, clock Clock) (*TrendingSorter, error) {
+	if halfLife <= 0 {
+		return nil, fmt.Errorf("trending half-life must be positive, got %s", halfLife)
+	}
+
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	s := &TrendingSorter{
+		halfLife: halfLife,
+		clock:    clock,
+	}
+	s.FieldSorter = newCatalogFieldSorter("Trending", s.scores, cmp.Compare[float64], false).
+		identify("trending", localizedLabel("%s", "Trending")).
+		describe(CategoryPopularity, priorityTrending, "Products selling fastest right now")
+	return s, nil
+}
+
+func (s *TrendingSorter) scores(products model.ProductList) []float64 {
+	now := s.clock.Now()
+
+	scores := make([]float64, len(products))
+	for i, p := range products {
+		scores[i] = s.score(p, now)
+	}
+	return scores
+}
+
+func (s *TrendingSorter) score(p *model.Product, now time.Time) float64 {
+	age := now.Sub(p.Created)
+	if age < 0 {
+		age = 0
+	}
+
+	ageDays := math.Max(age.Hours()/24, 1)
+	velocity := float64(p.SalesCount) / ageDays
+	decay := math.Exp2(-float64(age) / float64(s.halfLife))
+
+	return velocity * decay
+}
+
+func (s *TrendingSorter) HalfLife() time.Duration {
+	return s.halfLife
+}
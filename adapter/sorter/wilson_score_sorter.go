@@ -0,0 +1,86 @@
+package sorter
+
+import (
+	"fmt"
+	"math"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+const DefaultWilsonConfidence = 0.95
+
+type WilsonInterval struct {
+	Lower  float64
+	Center float64
+	Upper  float64
+}
+
+func (i WilsonInterval) String() string {
+	return fmt.Sprintf("[%.6f, %.6f]", i.Lower, i.Upper)
+}
+
+type WilsonScoreSorter struct {
+	*FieldSorter[float64]
+	confidence float64
+	z          float64
+}
+
+func NewWilsonScoreSorter(ascending bool, confidence float64) (*WilsonScoreSorter, error) {
+	if confidence <= 0 || confidence >= 1 {
+		return nil, fmt.Errorf("wilson confidence level must be between 0 and 1, got %v", confidence)
+	}
+
+	name := "Wilson Score (descending)"
+	if ascending {
+		name = "Wilson Score (ascending)"
+	}
+
+	s := &WilsonScoreSorter{
+		confidence: confidence,
+		z:          math.Sqrt2 * math.Erfinv(confidence),
+	}
+	s.FieldSorter = NewFieldSorter(name, func(p *model.Product) float64 { return s.Interval(p).Lower }, ascending).
+		identify(sorterID("wilson_score", ascending), directionalLabel("Wilson Score", ascending)).
+		describe(CategoryPopularity, priorityWilson, "Sales per view ranked by the confidence we have in it")
+	return s, nil
+}
+
+// Interval returns the Wilson score confidence interval of the product's
+// sales per view at the sorter's confidence level.
+func (s *WilsonScoreSorter) Interval(p *model.Product) WilsonInterval {
+	if p.ViewsCount <= 0 {
+		return WilsonInterval{}
+	}
+
+	n := float64(p.ViewsCount)
+	phat := math.Min(calculateSalesPerView(p), 1)
+	z2 := s.z * s.z
+
+	denominator := 1 + z2/n
+	center := (phat + z2/(2*n)) / denominator
+	margin := s.z * math.Sqrt(phat*(1-phat)/n+z2/(4*n*n)) / denominator
+
+	return WilsonInterval{
+		Lower:  math.Max(center-margin, 0),
+		Center: center,
+		Upper:  math.Min(center+margin, 1),
+	}
+}
+
+// Explain reports each product's lower bound, which it is ranked by, along
+// with the center and upper bound of its interval.
+func (s *WilsonScoreSorter) Explain(products model.ProductList) []service.RankExplanation {
+	return s.explain(products, func(i int) []service.ExplanationComponent {
+		interval := s.Interval(products[i])
+		return []service.ExplanationComponent{
+			{Name: "lower bound", Value: interval.Lower},
+			{Name: "center", Value: interval.Center},
+			{Name: "upper bound", Value: interval.Upper},
+		}
+	})
+}
+
+func (s *WilsonScoreSorter) Confidence() float64 {
+	return s.confidence
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"assessment/adapter/registry"
 	"assessment/adapter/sorter"
@@ -13,6 +16,20 @@ import (
 )
 
 func main() {
+	explain := flag.String("explain", "", "explain the ranking of the sorter with this ID")
+	locale := flag.String("locale", "en", "locale of sorter labels")
+	tenant := flag.String("tenant", "", "ID of the storefront whose sorters and settings to use")
+	migrateConfig := flag.String("migrate-config", "", "rewrite this config file in the current format and exit")
+	flag.Parse()
+
+	if *migrateConfig != "" {
+		if err := migrateConfigFile(*migrateConfig); err != nil {
+			fmt.Printf("Error migrating %s: %v\n", *migrateConfig, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize repository
 	repo := persistence.NewInMemoryProductRepository()
 	if repo == nil {
@@ -31,31 +48,72 @@ func main() {
 	configFile := "infrastructure/config/sample_config.json"
 
 	if _, err := os.Stat(configFile); err == nil {
-		if err := cfg.LoadFromFile(configFile); err != nil {
+		configManager, err := config.NewManager(configFile, validateSorterConfig)
+		if err != nil {
 			fmt.Printf("Warning: Failed to load config file: %v\n", err)
 		} else {
+			cfg = configManager.Current()
 			fmt.Println("Configuration loaded from", configFile)
 		}
 	} else {
 		fmt.Println("Using default configuration")
 	}
 
-	// Initialize sorter registry and use case
-	sorterRegistry := registry.NewSorterRegistry()
-	sorterUseCase := usecase.NewProductSorterUseCase(sorterRegistry)
-	sorterUseCase.SetConfig(cfg)
-	sorter.InitializeDefaultSorters(sorterRegistry, cfg)
+	// Initialize sorter registries and use case
+	sorterRegistries := registry.NewTenantSorterRegistry()
+	tenantUseCase := usecase.NewTenantProductSorterUseCase(sorterRegistries)
+	tenantUseCase.SetConfig(cfg)
+	tenantUseCase.SetExpressionParser(sorter.NewExpressionParser())
+	if err := sorter.InitializeTenantSorters(sorterRegistries, cfg, sorter.DefaultSorterFactories()); err != nil {
+		fmt.Printf("Warning: Failed to initialize sorters: %v\n", err)
+	}
+
+	sorterUseCase := tenantUseCase.ForTenant(*tenant)
+
+	if *explain != "" {
+		explainRanking(repo, sorterUseCase, *explain)
+		return
+	}
 
 	// Run the application
-	runApp(repo, sorterUseCase)
+	runApp(repo, sorterUseCase, *locale)
+}
+
+// migrateConfigFile rewrites a config file in an older format, such as that of
+// the legacy config package, in the current format
+func migrateConfigFile(filename string) error {
+	version, err := config.MigrateFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if version == config.CurrentVersion {
+		fmt.Printf("%s is already at version %d\n", filename, config.CurrentVersion)
+		return nil
+	}
+
+	fmt.Printf("Migrated %s from version %d to version %d\n", filename, version, config.CurrentVersion)
+	return nil
+}
+
+// validateSorterConfig rejects configs whose sorters cannot be built
+func validateSorterConfig(cfg *config.Config) error {
+	return sorter.InitializeTenantSorters(registry.NewTenantSorterRegistry(), cfg, sorter.DefaultSorterFactories())
 }
 
 // runApp runs the main application logic
-func runApp(repo *persistence.InMemoryProductRepository, sorterUseCase *usecase.ProductSorterUseCase) {
+func runApp(repo *persistence.InMemoryProductRepository, sorterUseCase *usecase.ProductSorterUseCase, locale string) {
 	// Display available sorters
 	fmt.Println("Available sorters:")
-	for _, name := range sorterUseCase.GetAvailableSorters() {
-		fmt.Printf("- %s\n", name)
+	for _, option := range sorterUseCase.GetSorterOptions(locale) {
+		marker := "-"
+		if option.Default {
+			marker = "*"
+		}
+		fmt.Printf("%s %s: %s [%s]\n", marker, option.ID, option.Label, option.Category)
+		if option.Description != "" {
+			fmt.Printf("    %s\n", option.Description)
+		}
 	}
 	fmt.Println()
 
@@ -67,7 +125,7 @@ func runApp(repo *persistence.InMemoryProductRepository, sorterUseCase *usecase.
 	}
 
 	// Display products sorted by price
-	sortedProducts, err := sorterUseCase.SortProducts(products, "Price (ascending)")
+	sortedProducts, err := sorterUseCase.SortProducts(products, "price:asc")
 	if err != nil {
 		fmt.Printf("Error sorting products by price: %v\n", err)
 		return
@@ -79,6 +137,55 @@ func runApp(repo *persistence.InMemoryProductRepository, sorterUseCase *usecase.
 	}
 }
 
+// explainRanking prints why each product is ranked where it is by the named sorter
+func explainRanking(repo *persistence.InMemoryProductRepository, sorterUseCase *usecase.ProductSorterUseCase, sorterID string) {
+	products, err := repo.GetAll()
+	if err != nil {
+		fmt.Printf("Error retrieving products: %v\n", err)
+		return
+	}
+
+	explanations, err := sorterUseCase.Explain(products, sorterID)
+	if err != nil {
+		fmt.Printf("Error explaining %s: %v\n", sorterID, err)
+		return
+	}
+
+	fmt.Printf("Ranking explained for %s:\n", sorterID)
+	for _, e := range explanations {
+		fmt.Printf("%d. ID: %d, Name: %s", e.Position, e.Product.ID, e.Product.Name)
+		if e.Key != nil {
+			fmt.Printf(", Key: %s", formatExplanationValue(e.Key))
+		}
+		fmt.Println()
+
+		for _, c := range e.Components {
+			fmt.Printf("   %s: %s\n", c.Name, formatExplanationValue(c.Value))
+		}
+		if len(e.TiedWith) > 0 {
+			fmt.Printf("   tied with %v", e.TiedWith)
+			if others := e.TieGroupSize - 1; others > len(e.TiedWith) {
+				fmt.Printf(" and %d more", others-len(e.TiedWith))
+			}
+			fmt.Printf(", broken by %s\n", e.TieBreak)
+		}
+		if len(e.Adjustments) > 0 {
+			fmt.Printf("   %s\n", strings.Join(e.Adjustments, ", "))
+		}
+	}
+}
+
+func formatExplanationValue(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return fmt.Sprintf("%.6g", v)
+	case time.Time:
+		return v.Format("2006-01-02")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 // loadSampleData loads sample product data into the repository
 func loadSampleData(repo *persistence.InMemoryProductRepository) error {
 	// Sample product data
@@ -1,3 +1,8 @@
+// Package config holds the legacy configuration format, a "sorters" object
+// keyed by field.
+//
+// Deprecated: Use assessment/infrastructure/config, which reads files in this
+// format and migrates them.
 package config
 
 import (
@@ -7,13 +12,11 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+
+	unified "assessment/infrastructure/config"
 )
 
-type SorterConfig struct {
-	Name      string `json:"name"`
-	Enabled   bool   `json:"enabled"`
-	Ascending bool   `json:"ascending"`
-}
+type SorterConfig = unified.LegacySorterConfig
 
 type Config struct {
 	Sorters map[string]SorterConfig `json:"sorters"`
@@ -129,3 +132,13 @@ func (c *Config) GetEnabledSorters() []string {
 	}
 	return enabled
 }
+
+// Migrate returns the settings of c in the unified config model.
+func (c *Config) Migrate() *unified.Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cfg := unified.NewConfig()
+	cfg.ApplyLegacySorters(c.Sorters)
+	return cfg
+}
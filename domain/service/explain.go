@@ -0,0 +1,66 @@
+package service
+
+import (
+	"assessment/domain/model"
+)
+
+// TieBreakProductID is the tie-break reported for products whose sort keys
+// are equal and which are therefore ordered by product ID.
+const TieBreakProductID = "product ID"
+
+// RankExplanation describes why a product landed at its position.
+type RankExplanation struct {
+	// Position is the product's 1-based position in the sorted list.
+	Position int
+	Product  *model.Product
+
+	// Key is the key or score the sorter ordered the product by, or nil when
+	// the sorter cannot explain itself.
+	Key any
+
+	// TieGroupSize is the number of products sharing Key, this one included,
+	// or 0 when it is not tied. TiedWith lists the IDs of at most MaxTiedWith
+	// of the others, those closest to it in the ranking, and TieBreak how the
+	// tie between them was resolved.
+	TieGroupSize int
+	TiedWith     []int
+	TieBreak     string
+
+	// Components breaks Key down for composite and weighted sorters.
+	Components []ExplanationComponent
+
+	// Adjustments lists the changes made on top of the sorter's order, such
+	// as merchandising rules.
+	Adjustments []string
+}
+
+// MaxTiedWith bounds RankExplanation.TiedWith, so that explaining a large
+// group of tied products takes memory linear in its size.
+const MaxTiedWith = 10
+
+type ExplanationComponent struct {
+	Name  string
+	Value any
+}
+
+// Explainer is implemented by sorters that can report how they ranked each
+// product. Explain returns the products in the same order as Sort.
+type Explainer interface {
+	Explain(products model.ProductList) []RankExplanation
+}
+
+// Explain explains sorter's ranking of products. For sorters that do not
+// implement Explainer only the positions are reported.
+func Explain(sorter Sorter, products model.ProductList) []RankExplanation {
+	if explainer, ok := sorter.(Explainer); ok {
+		return explainer.Explain(products)
+	}
+
+	sorted := sorter.Sort(products)
+
+	explanations := make([]RankExplanation, len(sorted))
+	for i, p := range sorted {
+		explanations[i] = RankExplanation{Position: i + 1, Product: p}
+	}
+	return explanations
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"assessment/domain/model"
+)
+
+type MerchandisingRuleType string
+
+const (
+	RulePin   MerchandisingRuleType = "pin"
+	RuleBoost MerchandisingRuleType = "boost"
+	RuleBury  MerchandisingRuleType = "bury"
+)
+
+// MerchandisingRule adjusts a sorter's output. ProductID, CreatedWithin and
+// ZeroViews select the products a rule applies to; a product must match every
+// selector that is set. Start and End bound when the rule is active and are
+// ignored when zero.
+type MerchandisingRule struct {
+	Type MerchandisingRuleType
+
+	ProductID     int
+	CreatedWithin time.Duration
+	ZeroViews     bool
+
+	// Position is the 1-based position a pinned product is moved to.
+	Position int
+
+	// Positions is how far a boosted product moves up.
+	Positions int
+
+	Start time.Time
+	End   time.Time
+}
+
+func (r MerchandisingRule) Validate() error {
+	switch r.Type {
+	case RulePin:
+		if r.ProductID == 0 || r.Position < 1 {
+			return fmt.Errorf("pin rule requires a product ID and a position of at least 1")
+		}
+	case RuleBoost:
+		if r.Positions < 1 {
+			return fmt.Errorf("boost rule requires positions of at least 1")
+		}
+		if !r.hasSelector() {
+			return fmt.Errorf("boost rule requires a product selector")
+		}
+	case RuleBury:
+		if !r.hasSelector() {
+			return fmt.Errorf("bury rule requires a product selector")
+		}
+	default:
+		return fmt.Errorf("unknown merchandising rule type: %s", r.Type)
+	}
+
+	if !r.Start.IsZero() && !r.End.IsZero() && r.End.Before(r.Start) {
+		return fmt.Errorf("%s rule ends before it starts", r.Type)
+	}
+
+	return nil
+}
+
+func (r MerchandisingRule) ActiveAt(t time.Time) bool {
+	if !r.Start.IsZero() && t.Before(r.Start) {
+		return false
+	}
+	if !r.End.IsZero() && !t.Before(r.End) {
+		return false
+	}
+	return true
+}
+
+func (r MerchandisingRule) Matches(p *model.Product, now time.Time) bool {
+	if r.ProductID != 0 && p.ID != r.ProductID {
+		return false
+	}
+	if r.CreatedWithin > 0 && now.Sub(p.Created) > r.CreatedWithin {
+		return false
+	}
+	if r.ZeroViews && p.ViewsCount != 0 {
+		return false
+	}
+	return true
+}
+
+func (r MerchandisingRule) hasSelector() bool {
+	return r.ProductID != 0 || r.CreatedWithin > 0 || r.ZeroViews
+}
+
+// ApplyMerchandising returns a reordered copy of sorted products. Rules active
+// at now are applied in three passes: buries move matches to the end, boosts
+// move matches up, and pins finally place products at fixed positions.
+func ApplyMerchandising(products model.ProductList, rules []MerchandisingRule, now time.Time) model.ProductList {
+	result := make(model.ProductList, len(products))
+	copy(result, products)
+
+	var active []MerchandisingRule
+	for _, rule := range rules {
+		if rule.ActiveAt(now) {
+			active = append(active, rule)
+		}
+	}
+	if len(active) == 0 {
+		return result
+	}
+
+	result = applyBuries(result, active, now)
+	result = applyBoosts(result, active, now)
+	return applyPins(result, active, now)
+}
+
+func applyBuries(products model.ProductList, rules []MerchandisingRule, now time.Time) model.ProductList {
+	result := make(model.ProductList, 0, len(products))
+	var buried model.ProductList
+	for _, p := range products {
+		if matchesAny(p, rules, RuleBury, now) {
+			buried = append(buried, p)
+			continue
+		}
+		result = append(result, p)
+	}
+	return append(result, buried...)
+}
+
+// applyBoosts moves each boosted product in front of the boost-th unboosted
+// product before it, so adjacent boosted products each pass that many
+// unboosted products and keep their order among themselves.
+func applyBoosts(products model.ProductList, rules []MerchandisingRule, now time.Time) model.ProductList {
+	positions := make(map[*model.Product]float64, len(products))
+	unboosted := 0
+	for _, p := range products {
+		boost := 0
+		for _, rule := range rules {
+			if rule.Type == RuleBoost && rule.Matches(p, now) {
+				boost += rule.Positions
+			}
+		}
+
+		// Half a position ahead so a boosted product lands in front of the
+		// unboosted product already at its target position.
+		if boost > 0 {
+			positions[p] = float64(max(unboosted-boost, 0)) - 0.5
+			continue
+		}
+		positions[p] = float64(unboosted)
+		unboosted++
+	}
+
+	sort.SliceStable(products, func(i, j int) bool {
+		return positions[products[i]] < positions[products[j]]
+	})
+	return products
+}
+
+func applyPins(products model.ProductList, rules []MerchandisingRule, now time.Time) model.ProductList {
+	var pins []MerchandisingRule
+	for _, rule := range rules {
+		if rule.Type == RulePin {
+			pins = append(pins, rule)
+		}
+	}
+	if len(pins) == 0 {
+		return products
+	}
+	sort.SliceStable(pins, func(i, j int) bool { return pins[i].Position < pins[j].Position })
+
+	pinned := make(map[int]*model.Product)
+	rest := make(model.ProductList, 0, len(products))
+	for _, p := range products {
+		if _, seen := pinned[p.ID]; !seen && matchesAny(p, pins, RulePin, now) {
+			pinned[p.ID] = p
+			continue
+		}
+		rest = append(rest, p)
+	}
+
+	for _, pin := range pins {
+		p, ok := pinned[pin.ProductID]
+		if !ok || !pin.Matches(p, now) {
+			continue
+		}
+		delete(pinned, pin.ProductID)
+
+		position := min(pin.Position-1, len(rest))
+		rest = append(rest, nil)
+		copy(rest[position+1:], rest[position:])
+		rest[position] = p
+	}
+
+	return rest
+}
+
+func matchesAny(p *model.Product, rules []MerchandisingRule, ruleType MerchandisingRuleType, now time.Time) bool {
+	for _, rule := range rules {
+		if rule.Type == ruleType && rule.Matches(p, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// MerchandisedSorter applies merchandising rules on top of another sorter.
+type MerchandisedSorter struct {
+	sorter Sorter
+	rules  []MerchandisingRule
+	now    func() time.Time
+}
+
+func NewMerchandisedSorter(sorter Sorter, rules []MerchandisingRule, now func() time.Time) *MerchandisedSorter {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &MerchandisedSorter{
+		sorter: sorter,
+		rules:  rules,
+		now:    now,
+	}
+}
+
+func (s *MerchandisedSorter) Sort(products model.ProductList) model.ProductList {
+	return ApplyMerchandising(s.sorter.Sort(products), s.rules, s.now())
+}
+
+func (s *MerchandisedSorter) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	sorted, err := AsSorterV2(s.sorter).SortContext(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+	return ApplyMerchandising(sorted, s.rules, s.now()), nil
+}
+
+// Explain explains the wrapped sorter's ranking and records the rules that
+// moved each product.
+func (s *MerchandisedSorter) Explain(products model.ProductList) []RankExplanation {
+	now := s.now()
+	explanations := Explain(s.sorter, products)
+
+	sorted := make(model.ProductList, len(explanations))
+	byProduct := make(map[*model.Product]RankExplanation, len(explanations))
+	for i, e := range explanations {
+		sorted[i] = e.Product
+		byProduct[e.Product] = e
+	}
+
+	merchandised := ApplyMerchandising(sorted, s.rules, now)
+	for i, p := range merchandised {
+		e := byProduct[p]
+		e.Position = i + 1
+		e.Adjustments = append(e.Adjustments, s.adjustments(p, now)...)
+		explanations[i] = e
+	}
+	return explanations
+}
+
+func (s *MerchandisedSorter) adjustments(p *model.Product, now time.Time) []string {
+	var adjustments []string
+	for _, rule := range s.rules {
+		if !rule.ActiveAt(now) || !rule.Matches(p, now) {
+			continue
+		}
+
+		switch rule.Type {
+		case RulePin:
+			adjustments = append(adjustments, fmt.Sprintf("pinned at position %d", rule.Position))
+		case RuleBoost:
+			adjustments = append(adjustments, fmt.Sprintf("boosted %d positions", rule.Positions))
+		case RuleBury:
+			adjustments = append(adjustments, "buried")
+		}
+	}
+	return adjustments
+}
+
+func (s *MerchandisedSorter) Name() string {
+	return s.sorter.Name()
+}
+
+func (s *MerchandisedSorter) Unwrap() Sorter {
+	return s.sorter
+}
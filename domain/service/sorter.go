@@ -10,12 +10,75 @@ type Sorter interface {
 	Name() string
 }
 
+// TopKSorter is implemented by sorters that can return just the first k
+// products of their ordering without sorting the whole list.
+type TopKSorter interface {
+	Sorter
+
+	SortTopK(products model.ProductList, k int) model.ProductList
+}
+
+// IDTieBreaker is implemented by sorters that break every tie on product ID,
+// so their order does not depend on the order of their input.
+type IDTieBreaker interface {
+	BreaksTiesByID() bool
+}
+
+// SorterWrapper is implemented by sorters that decorate another sorter.
+type SorterWrapper interface {
+	Unwrap() Sorter
+}
+
+// Unwrap returns the innermost sorter behind any SorterWrapper decorators.
+func Unwrap(sorter Sorter) Sorter {
+	for {
+		wrapper, ok := sorter.(SorterWrapper)
+		if !ok {
+			return sorter
+		}
+		sorter = wrapper.Unwrap()
+	}
+}
+
+type SortExpressionParser interface {
+	Parse(expression string) (Sorter, error)
+}
+
 type SorterRegistry interface {
+	// RegisterSorter replaces any sorter registered with the same ID. Use
+	// Register to detect conflicts instead.
 	RegisterSorter(sorter Sorter)
 
 	GetSorter(name string) (Sorter, bool)
 
+	// GetAllSorters returns the sorters in a stable order.
 	GetAllSorters() []Sorter
 
 	UnregisterSorter(name string) bool
 }
+
+// OrderedSorterRegistry is a SorterRegistry whose listing order can be
+// configured.
+type OrderedSorterRegistry interface {
+	SorterRegistry
+
+	// SetOrder lists the IDs or aliases of the sorters to list first.
+	SetOrder(order []string)
+}
+
+// TenantSorterRegistry holds a registry shared by all tenants and a namespace
+// per tenant on top of it.
+type TenantSorterRegistry interface {
+	Global() SorterRegistry
+
+	// Tenant returns the registry of tenantID. Sorters registered in it are
+	// visible to that tenant only and replace shared sorters with the same
+	// ID; other lookups fall back to the shared registry. Tenants not added
+	// with AddTenant see the shared registry only and cannot register
+	// sorters.
+	Tenant(tenantID string) SorterRegistry
+
+	// AddTenant creates the namespace of tenantID, if it does not exist yet,
+	// and returns its registry.
+	AddTenant(tenantID string) SorterRegistry
+}
@@ -0,0 +1,41 @@
+package service
+
+type SorterEventType string
+
+const (
+	SorterRegistered   SorterEventType = "registered"
+	SorterReplaced     SorterEventType = "replaced"
+	SorterUnregistered SorterEventType = "unregistered"
+)
+
+// SorterEvent reports a change to a SorterRegistry. Sorter is the sorter that
+// was registered, or the one removed for SorterUnregistered.
+type SorterEvent struct {
+	Type     SorterEventType
+	Name     string
+	Metadata SorterMetadata
+	Sorter   Sorter
+}
+
+func NewSorterEvent(eventType SorterEventType, sorter Sorter) SorterEvent {
+	return SorterEvent{
+		Type:     eventType,
+		Name:     sorter.Name(),
+		Metadata: DescribeSorter(sorter),
+		Sorter:   sorter,
+	}
+}
+
+// SorterObserver is called with each change to a registry it subscribed to.
+type SorterObserver func(event SorterEvent)
+
+// ObservableSorterRegistry is a SorterRegistry that notifies subscribers of
+// changes, so that sorter lists derived from it can be refreshed without
+// polling GetAllSorters.
+type ObservableSorterRegistry interface {
+	SorterRegistry
+
+	// Subscribe calls observer with every later change until the returned
+	// function is called.
+	Subscribe(observer SorterObserver) (unsubscribe func())
+}
@@ -0,0 +1,98 @@
+package service
+
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// IdentifiedSorter is implemented by sorters with a stable machine ID. Unlike
+// Name, the ID does not change with wording or locale, so it is what config,
+// the registry and API callers refer to a sorter by.
+type IdentifiedSorter interface {
+	Sorter
+
+	ID() string
+
+	// Direction is empty for sorters without one, such as a shuffle.
+	Direction() SortDirection
+
+	// Label returns the display name in the given locale, falling back to
+	// Name for locales without a translation.
+	Label(locale string) string
+}
+
+// AliasedSorter is implemented by sorters that can also be looked up by names
+// other than their ID and Name, such as legacy config keys.
+type AliasedSorter interface {
+	Aliases() []string
+}
+
+// identify returns the outermost IdentifiedSorter among sorter and the
+// sorters it wraps.
+func identify(sorter Sorter) (IdentifiedSorter, bool) {
+	for sorter != nil {
+		if identified, ok := sorter.(IdentifiedSorter); ok {
+			return identified, true
+		}
+
+		wrapper, ok := sorter.(SorterWrapper)
+		if !ok {
+			break
+		}
+		sorter = wrapper.Unwrap()
+	}
+	return nil, false
+}
+
+// SorterID returns the ID of sorter, or its Name when it has none.
+func SorterID(sorter Sorter) string {
+	if identified, ok := identify(sorter); ok {
+		return identified.ID()
+	}
+	return sorter.Name()
+}
+
+func SorterDirection(sorter Sorter) SortDirection {
+	if identified, ok := identify(sorter); ok {
+		return identified.Direction()
+	}
+	return ""
+}
+
+func SorterLabel(sorter Sorter, locale string) string {
+	if identified, ok := identify(sorter); ok {
+		return identified.Label(locale)
+	}
+	return sorter.Name()
+}
+
+// SorterAliases returns the names other than its ID that sorter can be looked
+// up by: its Name and any aliases it declares.
+func SorterAliases(sorter Sorter) []string {
+	id := SorterID(sorter)
+
+	var aliases []string
+	if name := sorter.Name(); name != id {
+		aliases = append(aliases, name)
+	}
+
+	for s := sorter; s != nil; {
+		if aliased, ok := s.(AliasedSorter); ok {
+			aliases = append(aliases, aliased.Aliases()...)
+			break
+		}
+		if _, ok := s.(IdentifiedSorter); ok {
+			break
+		}
+
+		wrapper, ok := s.(SorterWrapper)
+		if !ok {
+			break
+		}
+		s = wrapper.Unwrap()
+	}
+
+	return aliases
+}
@@ -0,0 +1,42 @@
+package service
+
+// SorterMetadata describes a sorter for rendering sort menus.
+type SorterMetadata struct {
+	ID          string
+	Description string
+	Category    string
+	Direction   SortDirection
+
+	// Priority orders sorters in listings, lowest first.
+	Priority int
+
+	// Default marks the sorter used when none is requested.
+	Default bool
+}
+
+// DescribedSorter is implemented by sorters that carry SorterMetadata.
+type DescribedSorter interface {
+	Metadata() SorterMetadata
+}
+
+// DescribeSorter returns the metadata of sorter or of the outermost sorter it
+// wraps that has any. ID and Direction are always those of sorter itself.
+func DescribeSorter(sorter Sorter) SorterMetadata {
+	var metadata SorterMetadata
+	for s := sorter; s != nil; {
+		if described, ok := s.(DescribedSorter); ok {
+			metadata = described.Metadata()
+			break
+		}
+
+		wrapper, ok := s.(SorterWrapper)
+		if !ok {
+			break
+		}
+		s = wrapper.Unwrap()
+	}
+
+	metadata.ID = SorterID(sorter)
+	metadata.Direction = SorterDirection(sorter)
+	return metadata
+}
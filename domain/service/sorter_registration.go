@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrSorterExists  = errors.New("sorter already registered")
+	ErrInvalidSorter = errors.New("invalid sorter")
+	ErrUnknownTenant = errors.New("unknown tenant")
+)
+
+// ConflictPolicy decides what registering a sorter does when its ID, Name or
+// an alias is already taken by another sorter.
+type ConflictPolicy int
+
+const (
+	// ConflictReject fails the registration with ErrSorterExists.
+	ConflictReject ConflictPolicy = iota
+
+	// ConflictReplace replaces every registered sorter whose ID, Name or an
+	// alias the new sorter takes.
+	ConflictReplace
+
+	// ConflictOverrideVersioned replaces the registered sorter only if the
+	// new one has a higher Version.
+	ConflictOverrideVersioned
+)
+
+func (p ConflictPolicy) String() string {
+	switch p {
+	case ConflictReject:
+		return "reject"
+	case ConflictReplace:
+		return "replace"
+	case ConflictOverrideVersioned:
+		return "override-versioned"
+	default:
+		return fmt.Sprintf("ConflictPolicy(%d)", int(p))
+	}
+}
+
+// VersionedSorter is implemented by sorters that can be overridden by newer
+// versions of themselves under ConflictOverrideVersioned. Sorters without a
+// version are version 0.
+type VersionedSorter interface {
+	Version() int
+}
+
+// SorterVersion returns the version of the outermost VersionedSorter among
+// sorter and the sorters it wraps.
+func SorterVersion(sorter Sorter) int {
+	for sorter != nil {
+		if versioned, ok := sorter.(VersionedSorter); ok {
+			return versioned.Version()
+		}
+
+		wrapper, ok := sorter.(SorterWrapper)
+		if !ok {
+			break
+		}
+		sorter = wrapper.Unwrap()
+	}
+	return 0
+}
+
+// SorterValidator checks a sorter before it is registered.
+type SorterValidator func(sorter Sorter) error
+
+// ValidateSorterName rejects sorters with an empty Name or ID.
+func ValidateSorterName(sorter Sorter) error {
+	if strings.TrimSpace(sorter.Name()) == "" {
+		return errors.New("sorter name is empty")
+	}
+	if strings.TrimSpace(SorterID(sorter)) == "" {
+		return fmt.Errorf("sorter %q has an empty ID", sorter.Name())
+	}
+	return nil
+}
+
+// CheckConflict returns nil if candidate may replace existing under policy.
+func CheckConflict(existing, candidate Sorter, policy ConflictPolicy) error {
+	switch policy {
+	case ConflictReplace:
+		return nil
+	case ConflictOverrideVersioned:
+		existingVersion, candidateVersion := SorterVersion(existing), SorterVersion(candidate)
+		if candidateVersion > existingVersion {
+			return nil
+		}
+		return fmt.Errorf("%w: %s version %d does not override version %d",
+			ErrSorterExists, SorterID(existing), candidateVersion, existingVersion)
+	default:
+		return fmt.Errorf("%w: %s", ErrSorterExists, SorterID(existing))
+	}
+}
+
+// PolicySorterRegistry is a SorterRegistry that reports registration
+// conflicts and validation failures instead of overwriting silently.
+type PolicySorterRegistry interface {
+	SorterRegistry
+
+	Register(sorter Sorter, policy ConflictPolicy) error
+}
+
+// Register registers sorter under policy. Registries that are not a
+// PolicySorterRegistry are checked for a sorter with the same ID only.
+func Register(registry SorterRegistry, sorter Sorter, policy ConflictPolicy) error {
+	if policyRegistry, ok := registry.(PolicySorterRegistry); ok {
+		return policyRegistry.Register(sorter, policy)
+	}
+
+	if err := ValidateSorterName(sorter); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSorter, err)
+	}
+
+	if existing, exists := registry.GetSorter(SorterID(sorter)); exists {
+		if err := CheckConflict(existing, sorter, policy); err != nil {
+			return err
+		}
+	}
+
+	registry.RegisterSorter(sorter)
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"assessment/domain/model"
+)
+
+// SorterV2 is a Sorter that can observe cancellation and report failures,
+// for example when it depends on an external model or service.
+type SorterV2 interface {
+	SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error)
+
+	Name() string
+}
+
+// AsSorterV2 returns sorter itself when it implements SorterV2, and otherwise
+// adapts it so that the context is checked before and after sorting.
+func AsSorterV2(sorter Sorter) SorterV2 {
+	if v2, ok := sorter.(SorterV2); ok {
+		return v2
+	}
+	return &sorterV2Adapter{sorter: sorter}
+}
+
+type sorterV2Adapter struct {
+	sorter Sorter
+}
+
+func (a *sorterV2Adapter) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sorted := a.sorter.Sort(products)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return sorted, nil
+}
+
+func (a *sorterV2Adapter) Sort(products model.ProductList) model.ProductList {
+	return a.sorter.Sort(products)
+}
+
+func (a *sorterV2Adapter) Name() string {
+	return a.sorter.Name()
+}
+
+func (a *sorterV2Adapter) Unwrap() Sorter {
+	return a.sorter
+}
@@ -1,3 +1,5 @@
 module assessment
 
 go 1.23
+
+require golang.org/x/text v0.21.0
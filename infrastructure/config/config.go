@@ -6,21 +6,211 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"assessment/domain/service"
 )
 
+type SortKeyConfig struct {
+	Field     string `json:"field"`
+	Ascending bool   `json:"ascending"`
+}
+
+// CompositeSorterConfig, ScoreProfileConfig and RankingModelConfig register a
+// sorter with the given ID, which defaults to the name in snake case.
+type CompositeSorterConfig struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Keys        []SortKeyConfig `json:"keys"`
+}
+
+type SmoothedConversionConfig struct {
+	PriorSales        float64 `json:"prior_sales"`
+	PriorViews        float64 `json:"prior_views"`
+	UseCatalogAverage bool    `json:"use_catalog_average"`
+}
+
+type WilsonScoreConfig struct {
+	ConfidenceLevel float64 `json:"confidence_level"`
+}
+
+type ScoreComponentConfig struct {
+	Signal        string  `json:"signal"`
+	Weight        float64 `json:"weight"`
+	Normalization string  `json:"normalization"`
+}
+
+type ScoreProfileConfig struct {
+	ID          string                 `json:"id,omitempty"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Ascending   bool                   `json:"ascending"`
+	Components  []ScoreComponentConfig `json:"components"`
+}
+
+// SorterConfig declares a sorter built by the factory registered for Type.
+// Params holds the settings of that type, such as the field of a "field"
+// sorter, and is decoded by the factory.
+type SorterConfig struct {
+	Type        string          `json:"type"`
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Params      json.RawMessage `json:"params,omitempty"`
+}
+
+type TrendingConfig struct {
+	HalfLifeDays float64 `json:"half_life_days"`
+}
+
+type NaturalNameSortConfig struct {
+	Enabled     bool     `json:"enabled"`
+	Normalizers []string `json:"normalizers"`
+	Articles    []string `json:"articles,omitempty"`
+}
+
+// RankingModelConfig registers a sorter named Name that ranks products with the
+// linear model stored in ModelFile.
+type RankingModelConfig struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ModelFile   string `json:"model_file"`
+}
+
+// JitterConfig refers to the sorter to perturb by its ID or an alias.
+type JitterConfig struct {
+	Sorter string `json:"sorter"`
+	Window int    `json:"window"`
+}
+
+// ShuffleConfig seeds the Shuffle sorter and registers a jitter variant of each
+// sorter listed in Jitter.
+type ShuffleConfig struct {
+	Seed   int64          `json:"seed"`
+	Jitter []JitterConfig `json:"jitter,omitempty"`
+}
+
+// MerchandisingRuleConfig is a pin, boost or bury rule. Start and End are
+// optional and bound when the rule is active.
+type MerchandisingRuleConfig struct {
+	Type              string     `json:"type"`
+	ProductID         int        `json:"product_id,omitempty"`
+	Position          int        `json:"position,omitempty"`
+	Positions         int        `json:"positions,omitempty"`
+	CreatedWithinDays float64    `json:"created_within_days,omitempty"`
+	ZeroViews         bool       `json:"zero_views,omitempty"`
+	Start             *time.Time `json:"start,omitempty"`
+	End               *time.Time `json:"end,omitempty"`
+}
+
+// Rule returns the merchandising rule r describes.
+func (r MerchandisingRuleConfig) Rule() service.MerchandisingRule {
+	rule := service.MerchandisingRule{
+		Type:          service.MerchandisingRuleType(r.Type),
+		ProductID:     r.ProductID,
+		CreatedWithin: time.Duration(r.CreatedWithinDays * float64(24*time.Hour)),
+		ZeroViews:     r.ZeroViews,
+		Position:      r.Position,
+		Positions:     r.Positions,
+	}
+	if r.Start != nil {
+		rule.Start = *r.Start
+	}
+	if r.End != nil {
+		rule.End = *r.End
+	}
+	return rule
+}
+
+// Config is the configuration of the application. LoadFromFile also reads
+// files in older formats and migrates them; see CurrentVersion.
 type Config struct {
+	Version int `json:"version,omitempty"`
+
 	DisabledSorters []string `json:"disabled_sorters"`
 
 	DefaultPageSize int `json:"default_page_size"`
+
+	// DefaultSorter is the ID of the sorter used when none is requested.
+	DefaultSorter string `json:"default_sorter,omitempty"`
+
+	// SorterOrder lists the IDs of the sorters to list first, in order.
+	// The others follow in their default order.
+	SorterOrder []string `json:"sorter_order,omitempty"`
+
+	// Sorters are built by the sorter factories of their type.
+	Sorters []SorterConfig `json:"sorters,omitempty"`
+
+	CompositeSorters []CompositeSorterConfig `json:"composite_sorters,omitempty"`
+
+	SmoothedConversion SmoothedConversionConfig `json:"smoothed_conversion"`
+
+	WilsonScore WilsonScoreConfig `json:"wilson_score"`
+
+	ScoreProfiles []ScoreProfileConfig `json:"score_profiles,omitempty"`
+
+	Trending TrendingConfig `json:"trending"`
+
+	NameLocales []string `json:"name_locales,omitempty"`
+
+	NaturalNameSort NaturalNameSortConfig `json:"natural_name_sort"`
+
+	RankingModels []RankingModelConfig `json:"ranking_models,omitempty"`
+
+	Shuffle ShuffleConfig `json:"shuffle"`
+
+	MerchandisingRules []MerchandisingRuleConfig `json:"merchandising_rules,omitempty"`
+
+	// MerchandisingRulesFile names a JSON file of rules that is read when the
+	// config is loaded, so campaigns can be edited without touching the config.
+	MerchandisingRulesFile string `json:"merchandising_rules_file,omitempty"`
+
+	// Tenants overrides settings per storefront, keyed by tenant ID.
+	Tenants map[string]TenantConfig `json:"tenants,omitempty"`
+
+	fileMerchandisingRules []MerchandisingRuleConfig
+}
+
+// TenantConfig overrides the top-level settings for one tenant. Fields that
+// are unset inherit the top-level value; an empty but present list, such as
+// "disabled_sorters": [], replaces the inherited one.
+type TenantConfig struct {
+	DisabledSorters    []string                  `json:"disabled_sorters"`
+	DefaultPageSize    int                       `json:"default_page_size,omitempty"`
+	DefaultSorter      string                    `json:"default_sorter,omitempty"`
+	SorterOrder        []string                  `json:"sorter_order"`
+	MerchandisingRules []MerchandisingRuleConfig `json:"merchandising_rules"`
+
+	// Sorters are registered for the tenant only, replacing any shared sorter
+	// with the same ID.
+	Sorters []SorterConfig `json:"sorters,omitempty"`
 }
 
 func NewConfig() *Config {
 	return &Config{
+		Version:         CurrentVersion,
 		DisabledSorters: []string{},
 		DefaultPageSize: 10,
+		SmoothedConversion: SmoothedConversionConfig{
+			PriorViews:        100,
+			UseCatalogAverage: true,
+		},
+		WilsonScore: WilsonScoreConfig{
+			ConfidenceLevel: 0.95,
+		},
+		Trending: TrendingConfig{
+			HalfLifeDays: 30,
+		},
+		NaturalNameSort: NaturalNameSortConfig{
+			Normalizers: []string{"lowercase"},
+		},
 	}
 }
 
+// LoadFromFile reads filename, migrating files in older formats to
+// CurrentVersion, and rejects invalid merchandising rules.
 func (c *Config) LoadFromFile(filename string) error {
 
 	// Validate filename to prevent path traversal
@@ -29,14 +219,147 @@ func (c *Config) LoadFromFile(filename string) error {
 		return fmt.Errorf("invalid filename path: potential directory traversal attempt")
 	}
 
+	data, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.decode(data); err != nil {
+		return err
+	}
+
+	if err := validateMerchandisingRuleConfigs(c.MerchandisingRules); err != nil {
+		return err
+	}
+	for tenantID, t := range c.Tenants {
+		if err := validateMerchandisingRuleConfigs(t.MerchandisingRules); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenantID, err)
+		}
+	}
+
+	if c.MerchandisingRulesFile != "" {
+		return c.LoadMerchandisingRules(c.MerchandisingRulesFile)
+	}
+	return nil
+}
+
+// LoadMerchandisingRules reads filename, a JSON array of merchandising rules,
+// replacing any rules previously loaded from a file.
+func (c *Config) LoadMerchandisingRules(filename string) error {
+
+	// Validate filename to prevent path traversal
+	cleanPath := filepath.Clean(filename)
+	if filepath.IsAbs(cleanPath) || strings.Contains(cleanPath, "..") {
+		return fmt.Errorf("invalid filename path: potential directory traversal attempt")
+	}
+
 	file, err := os.Open(cleanPath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	decoder := json.NewDecoder(file)
-	return decoder.Decode(c)
+	var rules []MerchandisingRuleConfig
+	if err := json.NewDecoder(file).Decode(&rules); err != nil {
+		return fmt.Errorf("invalid merchandising rules file %s: %w", filename, err)
+	}
+	if err := validateMerchandisingRuleConfigs(rules); err != nil {
+		return fmt.Errorf("invalid merchandising rules file %s: %w", filename, err)
+	}
+
+	c.fileMerchandisingRules = rules
+	return nil
+}
+
+// AllMerchandisingRules returns the inline rules followed by those loaded from
+// MerchandisingRulesFile.
+func (c *Config) AllMerchandisingRules() []MerchandisingRuleConfig {
+	rules := make([]MerchandisingRuleConfig, 0, len(c.MerchandisingRules)+len(c.fileMerchandisingRules))
+	rules = append(rules, c.MerchandisingRules...)
+	return append(rules, c.fileMerchandisingRules...)
+}
+
+// Validate checks the settings that can be checked without building the
+// sorters they describe.
+func (c *Config) Validate() error {
+	if c.DefaultPageSize <= 0 {
+		return fmt.Errorf("default_page_size must be positive, got %d", c.DefaultPageSize)
+	}
+
+	if err := validateSorterConfigs(c.Sorters); err != nil {
+		return err
+	}
+
+	if err := validateMerchandisingRuleConfigs(c.AllMerchandisingRules()); err != nil {
+		return err
+	}
+
+	for tenantID, t := range c.Tenants {
+		if tenantID == "" {
+			return fmt.Errorf("tenant ID is empty")
+		}
+		if t.DefaultPageSize < 0 {
+			return fmt.Errorf("tenant %s: default_page_size must be positive, got %d", tenantID, t.DefaultPageSize)
+		}
+		if err := validateSorterConfigs(t.Sorters); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenantID, err)
+		}
+		if err := validateMerchandisingRuleConfigs(t.MerchandisingRules); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenantID, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSorterConfigs(sorters []SorterConfig) error {
+	for i, s := range sorters {
+		if strings.TrimSpace(s.Type) == "" {
+			return fmt.Errorf("sorter %d has no type", i)
+		}
+	}
+	return nil
+}
+
+func validateMerchandisingRuleConfigs(rules []MerchandisingRuleConfig) error {
+	for i, r := range rules {
+		if err := r.Rule().Validate(); err != nil {
+			return fmt.Errorf("invalid merchandising rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ForTenant returns the config of tenantID: a copy of c with the overrides of
+// the tenant applied. Tenants without overrides get a copy of c.
+func (c *Config) ForTenant(tenantID string) *Config {
+	tenantConfig := *c
+	tenantConfig.Tenants = nil
+
+	t, exists := c.Tenants[tenantID]
+	if !exists {
+		return &tenantConfig
+	}
+
+	if t.DisabledSorters != nil {
+		tenantConfig.DisabledSorters = t.DisabledSorters
+	}
+	if t.DefaultPageSize > 0 {
+		tenantConfig.DefaultPageSize = t.DefaultPageSize
+	}
+	if t.DefaultSorter != "" {
+		tenantConfig.DefaultSorter = t.DefaultSorter
+	}
+	if t.SorterOrder != nil {
+		tenantConfig.SorterOrder = t.SorterOrder
+	}
+	if t.MerchandisingRules != nil {
+		tenantConfig.MerchandisingRules = t.MerchandisingRules
+		tenantConfig.MerchandisingRulesFile = ""
+		tenantConfig.fileMerchandisingRules = nil
+	}
+
+	return &tenantConfig
 }
 
 func (c *Config) SaveToFile(filename string) error {
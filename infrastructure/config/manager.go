@@ -0,0 +1,178 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Validator checks a config before a Manager makes it active.
+type Validator func(cfg *Config) error
+
+// Manager keeps the active config of a file, reloading it when the file, its
+// merchandising rules file or one of its ranking model files changes. A
+// changed config that fails validation is rejected and the last good one
+// stays active.
+type Manager struct {
+	filename   string
+	validators []Validator
+	current    atomic.Pointer[Config]
+
+	// watched holds the modification times of the files read for the last
+	// config, valid or not, so that an invalid file is reported once.
+	watched   map[string]time.Time
+	reloadMu  sync.Mutex
+	observers []managerSubscription
+	nextID    int
+	observeMu sync.Mutex
+}
+
+type managerSubscription struct {
+	id       int
+	observer func(cfg *Config)
+}
+
+// NewManager loads filename, which must pass Validate and validators.
+func NewManager(filename string, validators ...Validator) (*Manager, error) {
+	m := &Manager{
+		filename:   filename,
+		validators: validators,
+	}
+
+	cfg, watched, err := m.load()
+	m.watched = watched
+	if err != nil {
+		return nil, err
+	}
+
+	m.current.Store(cfg)
+	return m, nil
+}
+
+// Current returns the active config. It must not be modified.
+func (m *Manager) Current() *Config {
+	return m.current.Load()
+}
+
+// Subscribe calls observer with every config made active from now on, until
+// the returned function is called.
+func (m *Manager) Subscribe(observer func(cfg *Config)) func() {
+	m.observeMu.Lock()
+	defer m.observeMu.Unlock()
+
+	id := m.nextID
+	m.nextID++
+	m.observers = append(m.observers, managerSubscription{id: id, observer: observer})
+
+	return func() {
+		m.observeMu.Lock()
+		defer m.observeMu.Unlock()
+
+		m.observers = slices.DeleteFunc(m.observers, func(s managerSubscription) bool { return s.id == id })
+	}
+}
+
+// Reload reads the config file again and, if it is valid, makes it the
+// active config and notifies the subscribers. The active config is kept when
+// the file is invalid.
+func (m *Manager) Reload() error {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	cfg, watched, err := m.load()
+	m.watched = watched
+	if err != nil {
+		return err
+	}
+
+	m.current.Store(cfg)
+
+	m.observeMu.Lock()
+	observers := slices.Clone(m.observers)
+	m.observeMu.Unlock()
+
+	for _, s := range observers {
+		s.observer(cfg)
+	}
+	return nil
+}
+
+// Watch reloads the config whenever the modification time of the config file,
+// its merchandising rules file or one of its ranking model files changes,
+// checking every interval until ctx is done. Reload errors are passed to
+// onError, if set.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !m.modified() {
+			continue
+		}
+		if err := m.Reload(); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+func (m *Manager) modified() bool {
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	for file, modTime := range m.watched {
+		if !fileModTime(file).Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads and validates the config file, returning the modification
+// times of the files read.
+func (m *Manager) load() (*Config, map[string]time.Time, error) {
+	watched := map[string]time.Time{m.filename: fileModTime(m.filename)}
+
+	cfg := NewConfig()
+	err := cfg.LoadFromFile(m.filename)
+	if cfg.MerchandisingRulesFile != "" {
+		watched[cfg.MerchandisingRulesFile] = fileModTime(cfg.MerchandisingRulesFile)
+	}
+	for _, model := range cfg.RankingModels {
+		watched[model.ModelFile] = fileModTime(model.ModelFile)
+	}
+	if err != nil {
+		return nil, watched, fmt.Errorf("invalid config %s: %w", m.filename, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, watched, fmt.Errorf("invalid config %s: %w", m.filename, err)
+	}
+	for _, validate := range m.validators {
+		if err := validate(cfg); err != nil {
+			return nil, watched, fmt.Errorf("invalid config %s: %w", m.filename, err)
+		}
+	}
+
+	return cfg, watched, nil
+}
+
+// fileModTime returns the modification time of filename, or the zero time if
+// it cannot be read.
+func fileModTime(filename string) time.Time {
+	info, err := os.Stat(filepath.Clean(filename))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Versions of the config file format. Files without a "version" field are
+// read as VersionLegacy when their "sorters" field is an object and as
+// Version1 otherwise.
+const (
+	// VersionLegacy is the format of the deprecated assessment/config
+	// package: a "sorters" object of LegacySorterConfig keyed by field.
+	VersionLegacy = 0
+
+	// Version1 is the format of this package before it was versioned.
+	Version1 = 1
+
+	// Version2 adds the "version" field.
+	Version2 = 2
+
+	CurrentVersion = Version2
+)
+
+// LegacySorterConfig enables the ascending and descending sorters of a field
+// in the legacy format, Ascending being the direction listed first.
+type LegacySorterConfig struct {
+	Name      string `json:"name"`
+	Enabled   bool   `json:"enabled"`
+	Ascending bool   `json:"ascending"`
+}
+
+type legacyConfig struct {
+	Sorters map[string]LegacySorterConfig `json:"sorters"`
+}
+
+// legacyFields are the fields of the legacy format in listing order, with
+// the sorter ID prefix of each.
+var legacyFields = []struct {
+	key string
+	id  string
+}{
+	{key: "price", id: "price"},
+	{key: "sales_per_view", id: "sales_per_view"},
+	{key: "creation_date", id: "created"},
+	{key: "name", id: "name"},
+}
+
+// DetectVersion returns the format version of the config file data.
+func DetectVersion(data []byte) (int, error) {
+	var probe struct {
+		Version *int            `json:"version"`
+		Sorters json.RawMessage `json:"sorters"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return 0, err
+	}
+
+	if probe.Version != nil {
+		return *probe.Version, nil
+	}
+	if sorters := bytes.TrimSpace(probe.Sorters); len(sorters) > 0 && sorters[0] == '{' {
+		return VersionLegacy, nil
+	}
+	return Version1, nil
+}
+
+// ApplyLegacySorters migrates the sorter settings of the legacy format. Both
+// sorters of a field that is missing or disabled are disabled, and the
+// sorter in the configured direction of each enabled field is listed first.
+// Keys other than the legacy fields were ignored by the legacy format and
+// are ignored here too.
+func (c *Config) ApplyLegacySorters(sorters map[string]LegacySorterConfig) {
+	var order []string
+	for _, field := range legacyFields {
+		legacy, exists := sorters[field.key]
+		if !exists || !legacy.Enabled {
+			c.DisabledSorters = append(c.DisabledSorters, field.id+":asc", field.id+":desc")
+			continue
+		}
+
+		direction := "desc"
+		if legacy.Ascending {
+			direction = "asc"
+		}
+		order = append(order, field.id+":"+direction)
+	}
+
+	c.SorterOrder = order
+}
+
+// decode reads data in any supported format into c and migrates it to
+// CurrentVersion, returning the version data was in.
+func (c *Config) decode(data []byte) (int, error) {
+	version, err := DetectVersion(data)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case version == VersionLegacy:
+		var legacy legacyConfig
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return version, fmt.Errorf("invalid legacy config: %w", err)
+		}
+		c.ApplyLegacySorters(legacy.Sorters)
+	case version >= Version1 && version <= CurrentVersion:
+		if err := json.Unmarshal(data, c); err != nil {
+			return version, err
+		}
+	default:
+		return version, fmt.Errorf("unsupported config version %d, want at most %d", version, CurrentVersion)
+	}
+
+	c.Version = CurrentVersion
+	return version, nil
+}
+
+// MigrateFile rewrites filename in the current format and returns the version
+// it was in. Files already in the current format, and files whose migrated
+// config is invalid, are left as they are.
+func MigrateFile(filename string) (int, error) {
+
+	// Validate filename to prevent path traversal
+	cleanPath := filepath.Clean(filename)
+	if filepath.IsAbs(cleanPath) || strings.Contains(cleanPath, "..") {
+		return 0, fmt.Errorf("invalid filename path: potential directory traversal attempt")
+	}
+
+	data, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return 0, err
+	}
+
+	cfg := NewConfig()
+	version, err := cfg.decode(data)
+	if err != nil {
+		return version, err
+	}
+	if version == CurrentVersion {
+		return version, nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return version, fmt.Errorf("invalid config %s: %w", filename, err)
+	}
+	return version, cfg.SaveToFile(cleanPath)
+}
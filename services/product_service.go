@@ -3,9 +3,11 @@ package services
 import (
 	"assessment/adapter/registry"
 	"assessment/adapter/sorter"
-	"assessment/config"
 	"assessment/domain/model"
+	"assessment/domain/service"
+	"assessment/infrastructure/config"
 	"fmt"
+	"slices"
 )
 
 type ProductService struct {
@@ -20,6 +22,8 @@ func NewProductService(registry *registry.SorterRegistry) *ProductService {
 	}
 }
 
+// SetConfig sets the config. Configs of the deprecated assessment/config
+// package can be converted with its Migrate method.
 func (s *ProductService) SetConfig(cfg *config.Config) {
 	s.config = cfg
 }
@@ -52,31 +56,39 @@ func (s *ProductService) SortAndPaginateProducts(
 
 func (s *ProductService) GetAvailableSorters() []string {
 	sorters := s.registry.GetAllSorters()
-	names := make([]string, len(sorters))
+	ids := make([]string, len(sorters))
 
 	for i, sorter := range sorters {
-		names[i] = sorter.Name()
+		ids[i] = service.SorterID(sorter)
 	}
 
-	return names
+	return ids
 }
 
+// GetEnabledSorters returns the IDs of the registered sorters that are not
+// listed, by ID, name or alias, in the config's DisabledSorters.
 func (s *ProductService) GetEnabledSorters() []string {
-	allSorters := s.registry.GetAllSorters()
-	var enabledNames []string
+	var enabledIDs []string
+	for _, registered := range s.registry.GetAllSorters() {
+		id := service.SorterID(registered)
+		if !s.isSorterDisabled(id) {
+			enabledIDs = append(enabledIDs, id)
+		}
+	}
 
-	for _, sorter := range allSorters {
-		name := sorter.Name()
+	return enabledIDs
+}
 
-		for _, cfg := range s.config.Sorters {
-			if cfg.Name == name && cfg.Enabled {
-				enabledNames = append(enabledNames, name)
-				break
-			}
+func (s *ProductService) isSorterDisabled(id string) bool {
+	for _, disabled := range s.config.DisabledSorters {
+		if disabled == id {
+			return true
+		}
+		if registered, exists := s.registry.GetSorter(disabled); exists && service.SorterID(registered) == id {
+			return true
 		}
 	}
-
-	return enabledNames
+	return false
 }
 
 func (s *ProductService) CalculateSalesPerViewRatio(product *model.Product) float64 {
@@ -86,31 +98,30 @@ func (s *ProductService) CalculateSalesPerViewRatio(product *model.Product) floa
 	return float64(product.SalesCount) / float64(product.ViewsCount)
 }
 
+// InitializeDefaultSorters registers both directions of the price, sales per
+// view, creation date and name sorters, skipping fields whose sorters are
+// all disabled, and applies the config's listing order.
 func (s *ProductService) InitializeDefaultSorters() {
 
-	priceConfig, priceExists := s.config.GetSorterConfig("price")
-	salesConfig, salesExists := s.config.GetSorterConfig("sales_per_view")
-	dateConfig, dateExists := s.config.GetSorterConfig("creation_date")
-	nameConfig, nameExists := s.config.GetSorterConfig("name")
-
-	if priceExists && priceConfig.Enabled {
-		s.registry.RegisterSorter(sorter.NewPriceSorter(priceConfig.Ascending))
-
-		s.registry.RegisterSorter(sorter.NewPriceSorter(!priceConfig.Ascending))
+	fields := []func(ascending bool) service.Sorter{
+		func(ascending bool) service.Sorter { return sorter.NewPriceSorter(ascending) },
+		func(ascending bool) service.Sorter { return sorter.NewSalesPerViewSorter(ascending) },
+		func(ascending bool) service.Sorter { return sorter.NewDateSorter(ascending) },
+		func(ascending bool) service.Sorter { return sorter.NewNameSorter(ascending) },
 	}
 
-	if salesExists && salesConfig.Enabled {
-		s.registry.RegisterSorter(sorter.NewSalesPerViewSorter(salesConfig.Ascending))
-		s.registry.RegisterSorter(sorter.NewSalesPerViewSorter(!salesConfig.Ascending))
-	}
+	for _, newSorter := range fields {
+		ascending, descending := newSorter(true), newSorter(false)
+		if slices.Contains(s.config.DisabledSorters, service.SorterID(ascending)) &&
+			slices.Contains(s.config.DisabledSorters, service.SorterID(descending)) {
+			continue
+		}
 
-	if dateExists && dateConfig.Enabled {
-		s.registry.RegisterSorter(sorter.NewDateSorter(dateConfig.Ascending))
-		s.registry.RegisterSorter(sorter.NewDateSorter(!dateConfig.Ascending))
+		s.registry.RegisterSorter(ascending)
+		s.registry.RegisterSorter(descending)
 	}
 
-	if nameExists && nameConfig.Enabled {
-		s.registry.RegisterSorter(sorter.NewNameSorter(nameConfig.Ascending))
-		s.registry.RegisterSorter(sorter.NewNameSorter(!nameConfig.Ascending))
+	if len(s.config.SorterOrder) > 0 {
+		s.registry.SetOrder(s.config.SorterOrder)
 	}
 }
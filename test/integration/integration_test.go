@@ -41,8 +41,8 @@ func TestIntegration(t *testing.T) {
 	}
 
 	availableSorters := sorterUseCase.GetAvailableSorters()
-	if len(availableSorters) != 7 {
-		t.Errorf("Available sorters count mismatch: got %d, want %d", len(availableSorters), 7)
+	if len(availableSorters) != 13 {
+		t.Errorf("Available sorters count mismatch: got %d, want %d", len(availableSorters), 13)
 	}
 
 	for _, name := range availableSorters {
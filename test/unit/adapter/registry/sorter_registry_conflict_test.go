@@ -0,0 +1,195 @@
+package registry_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/service"
+)
+
+type VersionedMockSorter struct {
+	MockSorter
+	version int
+}
+
+func NewVersionedMockSorter(name string, version int) *VersionedMockSorter {
+	return &VersionedMockSorter{MockSorter: MockSorter{name: name}, version: version}
+}
+
+func (s *VersionedMockSorter) Version() int {
+	return s.version
+}
+
+func TestSorterRegistryRegisterRejectsDuplicates(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	original := NewMockSorter("MockSorter")
+	if err := reg.Register(original, service.ConflictReject); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	err := reg.Register(NewMockSorter("MockSorter"), service.ConflictReject)
+	if !errors.Is(err, service.ErrSorterExists) {
+		t.Errorf("Expected ErrSorterExists for a duplicate, got %v", err)
+	}
+
+	if s, _ := reg.GetSorter("MockSorter"); service.Unwrap(s) != original {
+		t.Error("Rejected registration replaced the original sorter")
+	}
+}
+
+func TestSorterRegistryRegisterRejectsNameOfAnotherSorter(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(sorter.NewPriceSorter(true))
+
+	err := reg.Register(NewMockSorter("Price (ascending)"), service.ConflictReject)
+	if !errors.Is(err, service.ErrSorterExists) {
+		t.Fatalf("Expected ErrSorterExists for a sorter named like price:asc, got %v", err)
+	}
+
+	if s, _ := reg.GetSorter("Price (ascending)"); service.SorterID(s) != "price:asc" {
+		t.Errorf("Price (ascending) resolves to %s, want price:asc", service.SorterID(s))
+	}
+}
+
+func TestSorterRegistryRegisterReplace(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(NewMockSorter("First"))
+	reg.RegisterSorter(NewMockSorter("MockSorter"))
+
+	replacement := NewMockSorter("MockSorter")
+	if err := reg.Register(replacement, service.ConflictReplace); err != nil {
+		t.Fatalf("Register with ConflictReplace failed: %v", err)
+	}
+
+	if s, _ := reg.GetSorter("MockSorter"); service.Unwrap(s) != replacement {
+		t.Error("ConflictReplace did not replace the sorter")
+	}
+
+	if got := sorterIDs(reg.GetAllSorters()); strings.Join(got, ",") != "First,MockSorter" {
+		t.Errorf("Replacing changed the listing: got %v", got)
+	}
+}
+
+type AliasedMockSorter struct {
+	MockSorter
+	aliases []string
+}
+
+func (s *AliasedMockSorter) Aliases() []string {
+	return s.aliases
+}
+
+func TestSorterRegistryRegisterReplaceThroughAlias(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(NewMockSorter("First"))
+	reg.RegisterSorter(NewMockSorter("a"))
+	reg.RegisterSorter(sorter.NewSalesPerViewSorter(false))
+
+	var events []string
+	reg.Subscribe(func(event service.SorterEvent) {
+		events = append(events, string(event.Type)+" "+event.Name)
+	})
+
+	replacement := &AliasedMockSorter{MockSorter: MockSorter{name: "b"}, aliases: []string{"a"}}
+	if err := reg.Register(replacement, service.ConflictReplace); err != nil {
+		t.Fatalf("Register with ConflictReplace failed: %v", err)
+	}
+
+	plugin := NewMockSorter("spv:desc")
+	if err := reg.Register(plugin, service.ConflictReplace); err != nil {
+		t.Fatalf("Register with ConflictReplace failed: %v", err)
+	}
+
+	if s, _ := reg.GetSorter("a"); service.Unwrap(s) != replacement {
+		t.Error("Alias a does not resolve to the sorter that took it over")
+	}
+	if s, _ := reg.GetSorter("spv:desc"); service.Unwrap(s) != plugin {
+		t.Error("spv:desc does not resolve to the sorter registered under it")
+	}
+	if _, exists := reg.GetSorter("sales_per_view:desc"); exists {
+		t.Error("sales_per_view:desc is still registered after spv:desc replaced it")
+	}
+
+	if got := sorterIDs(reg.GetAllSorters()); strings.Join(got, ",") != "First,b,spv:desc" {
+		t.Errorf("Replaced sorters are still listed: got %v", got)
+	}
+
+	want := []string{
+		"unregistered a",
+		"replaced b",
+		"unregistered Sales per View (descending)",
+		"replaced spv:desc",
+	}
+	if strings.Join(events, ",") != strings.Join(want, ",") {
+		t.Errorf("Events mismatch: got %v, want %v", events, want)
+	}
+}
+
+func TestSorterRegistryRegisterOverrideVersioned(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	if err := reg.Register(NewVersionedMockSorter("Plugin", 2), service.ConflictOverrideVersioned); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	for _, version := range []int{1, 2} {
+		err := reg.Register(NewVersionedMockSorter("Plugin", version), service.ConflictOverrideVersioned)
+		if !errors.Is(err, service.ErrSorterExists) {
+			t.Errorf("Expected version %d not to override version 2, got %v", version, err)
+		}
+	}
+
+	err := reg.Register(NewMockSorter("Plugin"), service.ConflictOverrideVersioned)
+	if !errors.Is(err, service.ErrSorterExists) {
+		t.Errorf("Expected an unversioned sorter not to override version 2, got %v", err)
+	}
+
+	newer := NewVersionedMockSorter("Plugin", 3)
+	if err := reg.Register(newer, service.ConflictOverrideVersioned); err != nil {
+		t.Fatalf("Expected version 3 to override version 2, got %v", err)
+	}
+
+	if s, _ := reg.GetSorter("Plugin"); service.SorterVersion(s) != 3 {
+		t.Errorf("Registered version mismatch: got %d, want %d", service.SorterVersion(s), 3)
+	}
+}
+
+func TestSorterRegistryValidation(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	err := reg.Register(NewMockSorter(" "), service.ConflictReplace)
+	if !errors.Is(err, service.ErrInvalidSorter) {
+		t.Errorf("Expected ErrInvalidSorter for an empty name, got %v", err)
+	}
+
+	reg.RegisterSorter(NewMockSorter(""))
+	if len(reg.GetAllSorters()) != 0 {
+		t.Error("RegisterSorter registered a sorter with an empty name")
+	}
+
+	reg.AddValidator(func(s service.Sorter) error {
+		if strings.HasPrefix(s.Name(), "Experimental") {
+			return errors.New("experimental sorters are disabled")
+		}
+		return nil
+	})
+
+	err = reg.Register(NewMockSorter("Experimental Sorter"), service.ConflictReject)
+	if !errors.Is(err, service.ErrInvalidSorter) || !strings.Contains(err.Error(), "experimental sorters are disabled") {
+		t.Errorf("Expected the custom validator to reject the sorter, got %v", err)
+	}
+
+	if err := reg.Register(NewMockSorter("MockSorter"), service.ConflictReject); err != nil {
+		t.Errorf("Register failed for a valid sorter: %v", err)
+	}
+}
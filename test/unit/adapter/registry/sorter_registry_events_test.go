@@ -0,0 +1,191 @@
+package registry_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/service"
+)
+
+func TestSorterRegistrySubscribe(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	var events []service.SorterEvent
+	unsubscribe := reg.Subscribe(func(event service.SorterEvent) {
+		events = append(events, event)
+
+		// Observers may read the registry.
+		reg.GetAllSorters()
+	})
+
+	reg.RegisterSorter(sorter.NewPriceSorter(true))
+	reg.RegisterSorter(sorter.NewPriceSorter(true))
+	if err := reg.Register(NewMockSorter("Price (ascending)"), service.ConflictReject); err == nil {
+		t.Fatal("Register did not return error for a conflicting sorter")
+	}
+	reg.UnregisterSorter("Price (ascending)")
+	reg.UnregisterSorter("Price (ascending)")
+
+	want := []service.SorterEventType{service.SorterRegistered, service.SorterReplaced, service.SorterUnregistered}
+	got := make([]service.SorterEventType, len(events))
+	for i, e := range events {
+		got[i] = e.Type
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Event types mismatch: got %v, want %v", got, want)
+	}
+
+	for _, e := range events {
+		if e.Name != "Price (ascending)" || e.Metadata.ID != "price:asc" || e.Metadata.Category != sorter.CategoryPrice {
+			t.Errorf("Event %s has name %q and metadata %+v", e.Type, e.Name, e.Metadata)
+		}
+	}
+
+	unsubscribe()
+	reg.RegisterSorter(NewMockSorter("MockSorter"))
+	if len(events) != 3 {
+		t.Errorf("Observer was called after unsubscribing: got %d events", len(events))
+	}
+}
+
+func TestSorterRegistryEventsChannel(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	events, cancel := reg.Events(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reg.RegisterSorter(NewMockSorter("First"))
+		reg.RegisterSorter(NewMockSorter("Second"))
+		reg.UnregisterSorter("First")
+	}()
+
+	var got []string
+	for range 3 {
+		select {
+		case e := <-events:
+			got = append(got, fmt.Sprintf("%s %s", e.Type, e.Name))
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for events, got %v", got)
+		}
+	}
+	<-done
+
+	want := []string{"registered First", "registered Second", "unregistered First"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Events mismatch: got %v, want %v", got, want)
+	}
+
+	cancel()
+	cancel()
+	if _, open := <-events; open {
+		t.Error("Events channel still open after cancel")
+	}
+
+	reg.RegisterSorter(NewMockSorter("Third"))
+}
+
+func TestSorterRegistryEventsCancelUnblocksRegistration(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	_, cancel := reg.Events(0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reg.RegisterSorter(NewMockSorter("Unread"))
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Registration still blocked after canceling the unread subscription")
+	}
+}
+
+func TestSorterRegistryConcurrentObservers(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	var (
+		mutex  sync.Mutex
+		counts = make(map[service.SorterEventType]int)
+	)
+	reg.Subscribe(func(event service.SorterEvent) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		counts[event.Type]++
+	})
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("MockSorter%d", i)
+			reg.RegisterSorter(NewMockSorter(name))
+			reg.UnregisterSorter(name)
+		}()
+	}
+	wg.Wait()
+
+	if counts[service.SorterRegistered] != 50 || counts[service.SorterUnregistered] != 50 {
+		t.Errorf("Event counts mismatch: got %v", counts)
+	}
+}
+
+func TestSorterRegistryObserverReadsDuringConcurrentRegistration(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	events, cancel := reg.Events(0)
+	defer cancel()
+
+	received := make(chan int)
+	go func() {
+		count := 0
+		for range events {
+			reg.GetAllSorters()
+			reg.GetSorter("MockSorter0")
+			count++
+			if count == 20 {
+				received <- count
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reg.RegisterSorter(NewMockSorter(fmt.Sprintf("MockSorter%d", i)))
+		}()
+	}
+
+	select {
+	case count := <-received:
+		if count != 20 {
+			t.Errorf("Received %d events, want 20", count)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out: registration and the observer deadlocked")
+	}
+	wg.Wait()
+
+	if got := len(reg.GetAllSorters()); got != 20 {
+		t.Errorf("Registered %d sorters, want 20", got)
+	}
+}
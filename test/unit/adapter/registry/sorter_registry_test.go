@@ -2,11 +2,14 @@ package registry_test
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 	"testing"
 
 	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
 	"assessment/domain/model"
+	"assessment/domain/service"
 )
 
 type MockSorter struct {
@@ -148,3 +151,146 @@ func TestSorterRegistryConcurrentAccess(t *testing.T) {
 		t.Errorf("Registry not empty after concurrent operations: got %d sorters", len(sorters))
 	}
 }
+
+type InputOrderSorter struct{}
+
+func (s *InputOrderSorter) Sort(products model.ProductList) model.ProductList {
+	return products.Clone()
+}
+
+func (s *InputOrderSorter) Name() string {
+	return "InputOrder"
+}
+
+func TestSorterRegistryDeterministicOutput(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	inner := &InputOrderSorter{}
+	reg.RegisterSorter(inner)
+
+	sorter, _ := reg.GetSorter("InputOrder")
+
+	forward := model.ProductList{{ID: 1}, {ID: 2}, {ID: 3}}
+	backward := model.ProductList{{ID: 3}, {ID: 2}, {ID: 1}}
+
+	sorted1 := sorter.Sort(forward)
+	sorted2 := sorter.Sort(backward)
+
+	for i := range sorted1 {
+		if sorted1[i].ID != sorted2[i].ID {
+			t.Fatalf("Registered sorter output depends on input order: %d != %d at %d", sorted1[i].ID, sorted2[i].ID, i)
+		}
+	}
+
+	if backward[0].ID != 3 {
+		t.Error("Original products were reordered by the registry")
+	}
+
+	if service.Unwrap(sorter) != inner {
+		t.Error("Unwrap did not return the registered sorter")
+	}
+}
+
+type IDTieBreakingSorter struct {
+	InputOrderSorter
+	inputs []model.ProductList
+}
+
+func (s *IDTieBreakingSorter) Sort(products model.ProductList) model.ProductList {
+	s.inputs = append(s.inputs, products)
+	return s.InputOrderSorter.Sort(products)
+}
+
+func (s *IDTieBreakingSorter) BreaksTiesByID() bool {
+	return true
+}
+
+func TestSorterRegistrySkipsIDOrderForIDTieBreakers(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	inner := &IDTieBreakingSorter{}
+	reg.RegisterSorter(inner)
+
+	sorter, _ := reg.GetSorter("InputOrder")
+
+	backward := model.ProductList{{ID: 3}, {ID: 2}, {ID: 1}}
+	sorter.Sort(backward)
+
+	if len(inner.inputs) != 1 || &inner.inputs[0][0] != &backward[0] {
+		t.Error("Registry reordered the input of a sorter that breaks ties on ID")
+	}
+}
+
+func TestSorterRegistryLookupByIDOrAlias(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(sorter.NewDateSorter(true))
+
+	for _, key := range []string{"created:asc", "Creation Date (ascending)", "creation_date:asc", "date:asc"} {
+		s, exists := reg.GetSorter(key)
+		if !exists {
+			t.Errorf("Sorter not found by %q", key)
+			continue
+		}
+
+		if id := service.SorterID(s); id != "created:asc" {
+			t.Errorf("Sorter ID mismatch for %q: got %s, want %s", key, id, "created:asc")
+		}
+	}
+
+	if !reg.UnregisterSorter("date:asc") {
+		t.Fatal("Sorter was not unregistered by alias")
+	}
+
+	for _, key := range []string{"created:asc", "Creation Date (ascending)", "creation_date:asc"} {
+		if _, exists := reg.GetSorter(key); exists {
+			t.Errorf("Sorter still found by %q after unregistering", key)
+		}
+	}
+}
+
+func sorterIDs(sorters []service.Sorter) []string {
+	ids := make([]string, len(sorters))
+	for i, s := range sorters {
+		ids[i] = service.SorterID(s)
+	}
+	return ids
+}
+
+func TestSorterRegistryListingOrder(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(NewMockSorter("Mock B"))
+	reg.RegisterSorter(sorter.NewNameSorter(true))
+	reg.RegisterSorter(NewMockSorter("Mock A"))
+	reg.RegisterSorter(sorter.NewPriceSorter(false))
+	reg.RegisterSorter(sorter.NewPriceSorter(true))
+
+	want := []string{"Mock B", "Mock A", "price:desc", "price:asc", "name:asc"}
+	for i := 0; i < 10; i++ {
+		if got := sorterIDs(reg.GetAllSorters()); !slices.Equal(got, want) {
+			t.Fatalf("Listing order mismatch: got %v, want %v", got, want)
+		}
+	}
+
+	reg.RegisterSorter(NewMockSorter("Mock B"))
+	if got := sorterIDs(reg.GetAllSorters()); !slices.Equal(got, want) {
+		t.Errorf("Re-registering moved the sorter: got %v, want %v", got, want)
+	}
+
+	reg.SetOrder([]string{"Name (ascending)", "price:asc", "unknown"})
+
+	want = []string{"name:asc", "price:asc", "Mock B", "Mock A", "price:desc"}
+	if got := sorterIDs(reg.GetAllSorters()); !slices.Equal(got, want) {
+		t.Errorf("Configured order mismatch: got %v, want %v", got, want)
+	}
+
+	reg.UnregisterSorter("Mock B")
+
+	want = []string{"name:asc", "price:asc", "Mock A", "price:desc"}
+	if got := sorterIDs(reg.GetAllSorters()); !slices.Equal(got, want) {
+		t.Errorf("Order after unregistering mismatch: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,180 @@
+package registry_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/service"
+)
+
+func TestTenantSorterRegistryFallsBackToGlobal(t *testing.T) {
+
+	reg := registry.NewTenantSorterRegistry()
+	reg.Global().RegisterSorter(sorter.NewPriceSorter(true))
+	reg.Global().RegisterSorter(sorter.NewPriceSorter(false))
+
+	outlet := reg.AddTenant("outlet")
+
+	if s, exists := outlet.GetSorter("Price (ascending)"); !exists || service.SorterID(s) != "price:asc" {
+		t.Fatal("Tenant did not inherit the global price:asc sorter")
+	}
+
+	outlet.RegisterSorter(NewMockSorter("Outlet Picks"))
+
+	if _, exists := reg.Global().GetSorter("Outlet Picks"); exists {
+		t.Error("Tenant sorter leaked into the global registry")
+	}
+	if _, exists := reg.Tenant("flagship").GetSorter("Outlet Picks"); exists {
+		t.Error("Tenant sorter leaked into another tenant")
+	}
+
+	if got := sorterIDs(outlet.GetAllSorters()); !slices.Equal(got, []string{"Outlet Picks", "price:asc", "price:desc"}) {
+		t.Errorf("Tenant listing mismatch: got %v", got)
+	}
+
+	if got := reg.Tenants(); !slices.Equal(got, []string{"outlet"}) {
+		t.Errorf("Tenants mismatch: got %v", got)
+	}
+}
+
+func TestTenantSorterRegistryOverride(t *testing.T) {
+
+	reg := registry.NewTenantSorterRegistry()
+	reg.Global().RegisterSorter(sorter.NewPriceSorter(true))
+	reg.Global().RegisterSorter(sorter.NewDateSorter(true))
+
+	outlet := reg.AddTenant("outlet")
+
+	override, err := sorter.NewCompositeSorter("Cheapest, best selling first",
+		sorter.SortKey{Field: "price", Ascending: true},
+		sorter.SortKey{Field: "sales", Ascending: false})
+	if err != nil {
+		t.Fatalf("NewCompositeSorter failed: %v", err)
+	}
+	override.SetID("price:asc")
+
+	if err := service.Register(outlet, override, service.ConflictReject); err != nil {
+		t.Fatalf("Tenant could not override a global sorter: %v", err)
+	}
+
+	for _, name := range []string{"price:asc", "Price (ascending)"} {
+		if s, _ := outlet.GetSorter(name); s.Name() != "Cheapest, best selling first" {
+			t.Errorf("%s resolves to %s for the tenant, want the override", name, s.Name())
+		}
+	}
+
+	if s, _ := reg.Global().GetSorter("price:asc"); s.Name() != "Price (ascending)" {
+		t.Errorf("Global price:asc was replaced by %s", s.Name())
+	}
+
+	// The override is listed by its own priority, that of composite sorters.
+	listed := outlet.GetAllSorters()
+	if got := sorterIDs(listed); !slices.Equal(got, []string{"created:asc", "price:asc"}) {
+		t.Fatalf("Tenant listing mismatch: got %v", got)
+	}
+	if listed[1].Name() != "Cheapest, best selling first" {
+		t.Errorf("Tenant listing shows %s instead of the override", listed[1].Name())
+	}
+
+	if !outlet.UnregisterSorter("Price (ascending)") {
+		t.Fatal("UnregisterSorter did not remove the override")
+	}
+	if s, _ := outlet.GetSorter("price:asc"); s.Name() != "Price (ascending)" {
+		t.Errorf("Removing the override did not reveal the global sorter, got %s", s.Name())
+	}
+	if outlet.UnregisterSorter("price:asc") {
+		t.Error("Tenant unregistered a global sorter")
+	}
+}
+
+func TestTenantSorterRegistryOrder(t *testing.T) {
+
+	reg := registry.NewTenantSorterRegistry()
+	reg.Global().RegisterSorter(sorter.NewPriceSorter(true))
+	reg.Global().RegisterSorter(sorter.NewDateSorter(true))
+	reg.Global().RegisterSorter(sorter.NewNameSorter(true))
+	reg.Global().(service.OrderedSorterRegistry).SetOrder([]string{"name:asc"})
+
+	outlet := reg.AddTenant("outlet")
+	outlet.RegisterSorter(NewMockSorter("Outlet Picks"))
+
+	if got := sorterIDs(outlet.GetAllSorters()); !slices.Equal(got, []string{"name:asc", "Outlet Picks", "price:asc", "created:asc"}) {
+		t.Errorf("Tenant listing without its own order mismatch: got %v", got)
+	}
+
+	outlet.(service.OrderedSorterRegistry).SetOrder([]string{"Outlet Picks", "Creation Date (ascending)"})
+
+	if got := sorterIDs(outlet.GetAllSorters()); !slices.Equal(got, []string{"Outlet Picks", "created:asc", "price:asc", "name:asc"}) {
+		t.Errorf("Tenant listing with its own order mismatch: got %v", got)
+	}
+
+	if got := sorterIDs(reg.Global().GetAllSorters()); !slices.Equal(got, []string{"name:asc", "price:asc", "created:asc"}) {
+		t.Errorf("Tenant order changed the global listing: got %v", got)
+	}
+}
+
+func TestTenantSorterRegistrySubscribe(t *testing.T) {
+
+	reg := registry.NewTenantSorterRegistry()
+	outlet := reg.AddTenant("outlet").(service.ObservableSorterRegistry)
+
+	var names []string
+	unsubscribe := outlet.Subscribe(func(event service.SorterEvent) {
+		names = append(names, event.Name)
+	})
+
+	reg.Global().RegisterSorter(NewMockSorter("Global"))
+	outlet.RegisterSorter(NewMockSorter("Outlet"))
+	reg.AddTenant("flagship").RegisterSorter(NewMockSorter("Flagship"))
+
+	unsubscribe()
+	reg.Global().RegisterSorter(NewMockSorter("Later"))
+
+	if !slices.Equal(names, []string{"Global", "Outlet"}) {
+		t.Errorf("Tenant events mismatch: got %v", names)
+	}
+
+	if !reg.RemoveTenant("outlet") || reg.RemoveTenant("outlet") {
+		t.Error("RemoveTenant result mismatch")
+	}
+	if _, exists := reg.Tenant("outlet").GetSorter("Outlet"); exists {
+		t.Error("Removed tenant kept its sorters")
+	}
+}
+
+func TestTenantSorterRegistryUnknownTenant(t *testing.T) {
+
+	reg := registry.NewTenantSorterRegistry()
+	reg.Global().RegisterSorter(sorter.NewPriceSorter(true))
+
+	for i := range 1000 {
+		unknown := reg.Tenant(fmt.Sprintf("tenant-%d", i))
+		if _, exists := unknown.GetSorter("price:asc"); !exists {
+			t.Fatal("Unknown tenant does not see the global sorters")
+		}
+	}
+	if got := reg.Tenants(); len(got) != 0 {
+		t.Errorf("Looking up unknown tenants created namespaces: %v", got)
+	}
+
+	unknown := reg.Tenant("unknown").(service.PolicySorterRegistry)
+	if err := unknown.Register(NewMockSorter("Unknown"), service.ConflictReplace); !errors.Is(err, service.ErrUnknownTenant) {
+		t.Errorf("Register on an unknown tenant returned %v, want ErrUnknownTenant", err)
+	}
+	unknown.RegisterSorter(NewMockSorter("Unknown"))
+	if _, exists := reg.Global().GetSorter("Unknown"); exists {
+		t.Error("Registering on an unknown tenant changed the global registry")
+	}
+	if got := sorterIDs(reg.Tenant("other").GetAllSorters()); !slices.Equal(got, []string{"price:asc"}) {
+		t.Errorf("Unknown tenant listing mismatch: got %v", got)
+	}
+
+	reg.AddTenant("outlet").RegisterSorter(NewMockSorter("Outlet"))
+	if _, exists := reg.Tenant("outlet").GetSorter("Outlet"); !exists {
+		t.Error("Added tenant lost its sorter")
+	}
+}
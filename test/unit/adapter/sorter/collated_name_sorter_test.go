@@ -0,0 +1,96 @@
+package sorter_test
+
+import (
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/infrastructure/config"
+)
+
+func createAccentedProducts() model.ProductList {
+	return model.ProductList{
+		{ID: 1, Name: "Zebra"},
+		{ID: 2, Name: "Émile"},
+		{ID: 3, Name: "Åsa"},
+		{ID: 4, Name: "anna"},
+		{ID: 5, Name: "Erik"},
+	}
+}
+
+func TestCollatedNameSorterGerman(t *testing.T) {
+
+	ascending, err := sorter.NewCollatedNameSorter(true, "de-DE")
+	if err != nil {
+		t.Fatalf("NewCollatedNameSorter failed: %v", err)
+	}
+
+	assertIDs(t, ascending.Sort(createAccentedProducts()), 4, 3, 2, 5, 1)
+
+	descending, err := sorter.NewCollatedNameSorter(false, "de-DE")
+	if err != nil {
+		t.Fatalf("NewCollatedNameSorter failed: %v", err)
+	}
+
+	assertIDs(t, descending.Sort(createAccentedProducts()), 1, 5, 2, 3, 4)
+
+	if ascending.Name() != "Name (ascending, de-DE)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", ascending.Name(), "Name (ascending, de-DE)")
+	}
+
+	if descending.Name() != "Name (descending, de-DE)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", descending.Name(), "Name (descending, de-DE)")
+	}
+}
+
+func TestCollatedNameSorterSwedish(t *testing.T) {
+
+	s, err := sorter.NewCollatedNameSorter(true, "sv-SE")
+	if err != nil {
+		t.Fatalf("NewCollatedNameSorter failed: %v", err)
+	}
+
+	assertIDs(t, s.Sort(createAccentedProducts()), 4, 2, 5, 1, 3)
+
+	if s.Locale() != "sv-SE" {
+		t.Errorf("Locale mismatch: got %s, want %s", s.Locale(), "sv-SE")
+	}
+}
+
+func TestCollatedNameSorterInvalidLocale(t *testing.T) {
+
+	if _, err := sorter.NewCollatedNameSorter(true, "not a locale"); err == nil {
+		t.Error("NewCollatedNameSorter did not return error for invalid locale")
+	}
+
+	cfg := config.NewConfig()
+	cfg.NameLocales = []string{"de-DE", "??"}
+
+	if err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg); err == nil {
+		t.Error("InitializeDefaultSorters did not return error for invalid locale")
+	}
+}
+
+func TestInitializeDefaultSortersWithNameLocales(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.NameLocales = []string{"de-DE", "sv-SE"}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	for _, name := range []string{
+		"Name (ascending, de-DE)",
+		"Name (descending, de-DE)",
+		"Name (ascending, sv-SE)",
+		"Name (descending, sv-SE)",
+	} {
+		if _, exists := reg.GetSorter(name); !exists {
+			t.Errorf("%s was not registered", name)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package sorter_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/domain/service"
+	"assessment/infrastructure/config"
+)
+
+func createTiedProducts() model.ProductList {
+
+	date, _ := time.Parse("2006-01-02", "2020-01-01")
+
+	return model.ProductList{
+		{ID: 1, Name: "Delta", Price: 10.0, Created: date, SalesCount: 10, ViewsCount: 100},
+		{ID: 2, Name: "Alpha", Price: 10.0, Created: date, SalesCount: 50, ViewsCount: 100},
+		{ID: 3, Name: "Charlie", Price: 5.0, Created: date, SalesCount: 10, ViewsCount: 100},
+		{ID: 4, Name: "Bravo", Price: 10.0, Created: date, SalesCount: 10, ViewsCount: 100},
+	}
+}
+
+func productIDs(products model.ProductList) []int {
+	ids := make([]int, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, products model.ProductList, want ...int) {
+	t.Helper()
+
+	got := productIDs(products)
+	if len(got) != len(want) {
+		t.Fatalf("Product count mismatch: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Product order mismatch: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCompositeSorter(t *testing.T) {
+
+	products := createTiedProducts()
+
+	composite, err := sorter.NewCompositeSorter("",
+		sorter.SortKey{Field: "price", Ascending: true},
+		sorter.SortKey{Field: "sales_per_view", Ascending: false},
+		sorter.SortKey{Field: "name", Ascending: true},
+	)
+	if err != nil {
+		t.Fatalf("NewCompositeSorter failed: %v", err)
+	}
+
+	sorted := composite.Sort(products)
+
+	assertIDs(t, sorted, 3, 2, 4, 1)
+
+	if products[0].ID != 1 || products[3].ID != 4 {
+		t.Error("Original products were modified")
+	}
+
+	want := "Price (ascending), then Sales per View (descending), then Name (ascending)"
+	if composite.Name() != want {
+		t.Errorf("Sorter name mismatch: got %s, want %s", composite.Name(), want)
+	}
+}
+
+func TestCompositeSorterTopKMatchesSort(t *testing.T) {
+
+	products := createRandomProducts(1000, 1)
+	for i, p := range products {
+		if i%2 == 0 {
+			p.Name = strings.ToUpper(p.Name)
+		}
+	}
+
+	composite, _ := sorter.NewCompositeSorter("",
+		sorter.SortKey{Field: "sales_per_view", Ascending: false},
+		sorter.SortKey{Field: "name", Ascending: true},
+	)
+
+	sorted := composite.Sort(products)
+	assertIDs(t, composite.SortTopK(products, 25), productIDs(sorted[:25])...)
+
+	salesPerView := func(p *model.Product) float64 {
+		if p.ViewsCount == 0 {
+			return 0
+		}
+		return float64(p.SalesCount) / float64(p.ViewsCount)
+	}
+	for i := 1; i < len(sorted); i++ {
+		a, b := sorted[i-1], sorted[i]
+		if salesPerView(a) < salesPerView(b) ||
+			salesPerView(a) == salesPerView(b) && strings.ToLower(a.Name) > strings.ToLower(b.Name) {
+			t.Fatalf("Products %d and %d out of order", a.ID, b.ID)
+		}
+	}
+}
+
+func BenchmarkCompositeSorter(b *testing.B) {
+
+	products := createRandomProducts(500000, 1)
+	composite, _ := sorter.NewCompositeSorter("",
+		sorter.SortKey{Field: "sales_per_view", Ascending: false},
+		sorter.SortKey{Field: "name", Ascending: true},
+	)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		composite.Sort(products)
+	}
+}
+
+func TestCompositeSorterAliasesAndName(t *testing.T) {
+
+	composite, err := sorter.NewCompositeSorter("Newest first",
+		sorter.SortKey{Field: "creation_date", Ascending: false},
+		sorter.SortKey{Field: " SPV ", Ascending: true},
+	)
+	if err != nil {
+		t.Fatalf("NewCompositeSorter failed: %v", err)
+	}
+
+	if composite.Name() != "Newest first" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", composite.Name(), "Newest first")
+	}
+
+	keys := composite.Keys()
+	if keys[0].Field != "created" || keys[1].Field != "sales_per_view" {
+		t.Errorf("Sort keys were not canonicalized: got %v", keys)
+	}
+}
+
+func TestCompositeSorterInvalidKeys(t *testing.T) {
+
+	if _, err := sorter.NewCompositeSorter("empty"); err == nil {
+		t.Error("NewCompositeSorter did not return error for empty keys")
+	}
+
+	if _, err := sorter.NewCompositeSorter("bad", sorter.SortKey{Field: "color"}); err == nil {
+		t.Error("NewCompositeSorter did not return error for unknown field")
+	}
+}
+
+func TestInitializeDefaultSortersWithComposite(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.CompositeSorters = []config.CompositeSorterConfig{
+		{
+			Name: "Cheapest then best selling",
+			Keys: []config.SortKeyConfig{
+				{Field: "price", Ascending: true},
+				{Field: "sales_per_view", Ascending: false},
+			},
+		},
+	}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	composite, exists := reg.GetSorter("Cheapest then best selling")
+	if !exists {
+		t.Fatal("Composite sorter from config was not registered")
+	}
+
+	assertIDs(t, composite.Sort(createTiedProducts()), 3, 2, 1, 4)
+
+	cfg.CompositeSorters = []config.CompositeSorterConfig{
+		{Name: "Broken", Keys: []config.SortKeyConfig{{Field: "unknown"}}},
+	}
+
+	if err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg); err == nil {
+		t.Error("InitializeDefaultSorters did not return error for invalid composite sorter")
+	}
+}
+
+func TestInitializeDefaultSortersRejectsConflictingComposite(t *testing.T) {
+
+	cfg := config.NewConfig()
+	cfg.CompositeSorters = []config.CompositeSorterConfig{
+		{ID: "price:asc", Name: "Cheapest first", Keys: []config.SortKeyConfig{{Field: "price", Ascending: true}}},
+	}
+
+	reg := registry.NewSorterRegistry()
+	err := sorter.InitializeDefaultSorters(reg, cfg)
+	if !errors.Is(err, service.ErrSorterExists) {
+		t.Fatalf("Expected ErrSorterExists for a composite reusing price:asc, got %v", err)
+	}
+
+	if s, _ := reg.GetSorter("price:asc"); s.Name() != "Price (ascending)" {
+		t.Errorf("Built-in price:asc was replaced by %s", s.Name())
+	}
+}
@@ -0,0 +1,160 @@
+package sorter_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+func explainedIDs(explanations []service.RankExplanation) []int {
+	ids := make([]int, len(explanations))
+	for i, e := range explanations {
+		ids[i] = e.Product.ID
+	}
+	return ids
+}
+
+func TestFieldSorterExplain(t *testing.T) {
+
+	products := model.ProductList{
+		{ID: 3, Price: 20},
+		{ID: 1, Price: 10},
+		{ID: 2, Price: 20},
+	}
+
+	s := sorter.NewPriceSorter(true)
+	explanations := s.Explain(products)
+
+	if !slices.Equal(explainedIDs(explanations), productIDs(s.Sort(products))) {
+		t.Fatalf("Explain order %v does not match Sort", explainedIDs(explanations))
+	}
+
+	first := explanations[0]
+	if first.Position != 1 || first.Key != 10.0 || len(first.TiedWith) != 0 || first.TieBreak != "" {
+		t.Errorf("Unexpected explanation for untied product: %+v", first)
+	}
+
+	for _, e := range explanations[1:] {
+		if e.Key != 20.0 || len(e.TiedWith) != 1 || e.TieBreak != service.TieBreakProductID {
+			t.Errorf("Tie not explained for product %d: %+v", e.Product.ID, e)
+		}
+	}
+
+	if explanations[1].TiedWith[0] != 3 || explanations[2].TiedWith[0] != 2 {
+		t.Error("Tied products not reported")
+	}
+
+	if explanations[0].Product == products[1] {
+		t.Error("Explain did not clone products")
+	}
+}
+
+func TestFieldSorterExplainBoundsLargeTies(t *testing.T) {
+
+	products := make(model.ProductList, 5000)
+	for i := range products {
+		products[i] = &model.Product{ID: i + 1, Price: 10}
+	}
+
+	explanations := sorter.NewPriceSorter(true).Explain(products)
+
+	for _, i := range []int{0, 2500, 4999} {
+		e := explanations[i]
+		if e.TieGroupSize != 5000 || len(e.TiedWith) != service.MaxTiedWith || slices.Contains(e.TiedWith, e.Product.ID) {
+			t.Errorf("Unexpected tie explanation at %d: size %d, tied with %v", i, e.TieGroupSize, e.TiedWith)
+		}
+	}
+
+	if want := []int{2496, 2497, 2498, 2499, 2500, 2502, 2503, 2504, 2505, 2506}; !slices.Equal(explanations[2500].TiedWith, want) {
+		t.Errorf("Tied products are not the nearest ones: got %v, want %v", explanations[2500].TiedWith, want)
+	}
+}
+
+func TestCompositeSorterExplain(t *testing.T) {
+
+	composite, _ := sorter.NewCompositeSorter("", sorter.SortKey{Field: "price", Ascending: true}, sorter.SortKey{Field: "sales"})
+	products := createTiedProducts()
+
+	explanations := composite.Explain(products)
+
+	if !slices.Equal(explainedIDs(explanations), productIDs(composite.Sort(products))) {
+		t.Fatalf("Explain order %v does not match Sort", explainedIDs(explanations))
+	}
+
+	for _, e := range explanations {
+		if len(e.Components) != 2 {
+			t.Fatalf("Component count mismatch: got %d, want %d", len(e.Components), 2)
+		}
+		if e.Components[0].Name != "Price (ascending)" || e.Components[0].Value != e.Product.Price {
+			t.Errorf("Price component mismatch: %+v", e.Components[0])
+		}
+		if e.Components[1].Name != "Sales (descending)" || e.Components[1].Value != e.Product.SalesCount {
+			t.Errorf("Sales component mismatch: %+v", e.Components[1])
+		}
+	}
+}
+
+func TestScoreSorterExplain(t *testing.T) {
+
+	s, _ := sorter.NewScoreSorter("Score", false,
+		sorter.ScoreComponent{Signal: "sales", Weight: 2, Normalization: sorter.NormalizeMinMax},
+		sorter.ScoreComponent{Signal: "price", Weight: -1, Normalization: sorter.NormalizeMinMax},
+	)
+	products := createScoreTestProducts()
+
+	explanations := s.Explain(products)
+
+	if !slices.Equal(explainedIDs(explanations), productIDs(s.Sort(products))) {
+		t.Fatalf("Explain order %v does not match Sort", explainedIDs(explanations))
+	}
+
+	for _, e := range explanations {
+		var sum float64
+		for _, c := range e.Components {
+			sum += c.Value.(float64)
+		}
+
+		if math.Abs(sum-e.Key.(float64)) > 1e-9 {
+			t.Errorf("Components of product %d sum to %v, want %v", e.Product.ID, sum, e.Key)
+		}
+	}
+
+	if explanations[0].Components[0].Name != "sales (min_max, weight 2)" {
+		t.Errorf("Component name mismatch: got %s", explanations[0].Components[0].Name)
+	}
+}
+
+func TestWilsonScoreSorterExplain(t *testing.T) {
+
+	s, _ := sorter.NewWilsonScoreSorter(false, sorter.DefaultWilsonConfidence)
+	products := model.ProductList{
+		{ID: 1, SalesCount: 5, ViewsCount: 10},
+		{ID: 2, SalesCount: 50, ViewsCount: 100},
+		{ID: 3, SalesCount: 0, ViewsCount: 0},
+	}
+
+	explanations := s.Explain(products)
+
+	if !slices.Equal(explainedIDs(explanations), productIDs(s.Sort(products))) {
+		t.Fatalf("Explain order %v does not match Sort", explainedIDs(explanations))
+	}
+
+	for _, e := range explanations {
+		interval := s.Interval(e.Product)
+		want := []service.ExplanationComponent{
+			{Name: "lower bound", Value: interval.Lower},
+			{Name: "center", Value: interval.Center},
+			{Name: "upper bound", Value: interval.Upper},
+		}
+		if !slices.Equal(e.Components, want) {
+			t.Errorf("Components of product %d mismatch: got %+v, want %+v", e.Product.ID, e.Components, want)
+		}
+		if e.Key != interval.Lower {
+			t.Errorf("Key of product %d mismatch: got %v, want %v", e.Product.ID, e.Key, interval.Lower)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package sorter_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+func TestFieldSorter(t *testing.T) {
+
+	products := createTestProducts()
+
+	var s service.Sorter = sorter.NewFieldSorter("Views (descending)", func(p *model.Product) int {
+		return p.SalesCount
+	}, false)
+
+	sorted := s.Sort(products)
+
+	assertIDs(t, sorted, 3, 2, 1)
+	verifyOriginalUnchanged(t, products, sorted)
+
+	if s.Name() != "Views (descending)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", s.Name(), "Views (descending)")
+	}
+
+	if _, ok := s.(service.TopKSorter); !ok {
+		t.Error("FieldSorter does not implement TopKSorter")
+	}
+}
+
+func TestFieldSorterComputesKeysOnce(t *testing.T) {
+
+	products := createRandomProducts(1000, 3)
+
+	calls := 0
+	s := sorter.NewFieldSorter("Name length", func(p *model.Product) int {
+		calls++
+		return len(p.Name)
+	}, true)
+
+	s.Sort(products)
+
+	if calls != len(products) {
+		t.Errorf("Key extractor called %d times, want %d", calls, len(products))
+	}
+
+	calls = 0
+	s.SortTopK(products, 10)
+
+	if calls != len(products) {
+		t.Errorf("Key extractor called %d times for top-K, want %d", calls, len(products))
+	}
+}
+
+func TestFieldSorterFunc(t *testing.T) {
+
+	products := createTestProducts()
+
+	s := sorter.NewFieldSorterFunc("Newest", func(p *model.Product) time.Time {
+		return p.Created
+	}, time.Time.Compare, false)
+
+	assertIDs(t, s.Sort(products), 3, 1, 2)
+
+	if s.Ascending() {
+		t.Error("Descending field sorter reports ascending")
+	}
+
+	byName := sorter.NewFieldSorterFunc("Name", func(p *model.Product) string { return p.Name }, strings.Compare, true)
+	assertIDs(t, byName.Sort(products), 3, 1, 2)
+}
@@ -0,0 +1,170 @@
+package sorter_test
+
+import (
+	"os"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/infrastructure/config"
+)
+
+func createRankingTestProducts() model.ProductList {
+	return model.ProductList{
+		{ID: 1, Price: 10, SalesCount: 10, ViewsCount: 100},
+		{ID: 2, Price: 50, SalesCount: 30, ViewsCount: 100},
+		{ID: 3, Price: 30, SalesCount: 0, ViewsCount: 5000},
+	}
+}
+
+func writeModelFile(t *testing.T, filename, contents string) {
+	t.Helper()
+
+	if err := os.WriteFile(filename, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Failed to write model file: %v", err)
+	}
+}
+
+func TestLinearModelSorter(t *testing.T) {
+
+	m := &sorter.LinearModel{
+		Features: []sorter.ModelFeature{
+			{Name: "sales_per_view", Weight: 1, Transform: sorter.TransformStandardize, Mean: 0.1, StdDev: 0.1},
+			{Name: "log_views", Weight: 0.5},
+		},
+		Bias: 1,
+	}
+
+	s, err := sorter.NewLinearModelSorter("Learned Ranking", m)
+	if err != nil {
+		t.Fatalf("NewLinearModelSorter failed: %v", err)
+	}
+
+	products := createRankingTestProducts()
+	assertIDs(t, s.Sort(products), 2, 3, 1)
+
+	if s.Name() != "Learned Ranking" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", s.Name(), "Learned Ranking")
+	}
+
+	if err := s.Swap(&sorter.LinearModel{Features: []sorter.ModelFeature{{Name: "price", Weight: -1}}}); err != nil {
+		t.Fatalf("Swap failed: %v", err)
+	}
+	assertIDs(t, s.Sort(products), 1, 3, 2)
+
+	if err := s.Swap(&sorter.LinearModel{Features: []sorter.ModelFeature{{Name: "color", Weight: 1}}}); err == nil {
+		t.Error("Swap did not return error for an unknown feature")
+	}
+	assertIDs(t, s.Sort(products), 1, 3, 2)
+}
+
+func TestLinearModelValidate(t *testing.T) {
+
+	invalid := []sorter.LinearModel{
+		{},
+		{Features: []sorter.ModelFeature{{Name: "color", Weight: 1}}},
+		{Features: []sorter.ModelFeature{{Name: "price", Weight: 1, Transform: "cube"}}},
+		{Features: []sorter.ModelFeature{{Name: "price", Weight: 1, Transform: sorter.TransformStandardize}}},
+	}
+
+	for _, m := range invalid {
+		if err := m.Validate(); err == nil {
+			t.Errorf("Validate did not return error for %+v", m)
+		}
+	}
+}
+
+func TestLinearModelSorterReload(t *testing.T) {
+
+	modelFile := "temp_linear_model_test.json"
+	defer os.Remove(modelFile)
+
+	writeModelFile(t, modelFile, `{"features": [{"name": "price", "weight": 1}], "bias": 0}`)
+
+	s, err := sorter.NewLinearModelSorterFromFile("Learned Ranking", modelFile)
+	if err != nil {
+		t.Fatalf("NewLinearModelSorterFromFile failed: %v", err)
+	}
+
+	products := createRankingTestProducts()
+	assertIDs(t, s.Sort(products), 2, 3, 1)
+
+	writeModelFile(t, modelFile, `{"version": "2", "features": [{"name": "price", "weight": -1}], "bias": 0}`)
+	if err := s.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	assertIDs(t, s.Sort(products), 1, 3, 2)
+
+	writeModelFile(t, modelFile, `{"features": []}`)
+	if err := s.Reload(); err == nil {
+		t.Error("Reload did not return error for an invalid model")
+	}
+
+	if s.Model().Version != "2" {
+		t.Errorf("Invalid model replaced the current one: got version %q", s.Model().Version)
+	}
+}
+
+func TestReloadRankingModels(t *testing.T) {
+
+	modelFile := "temp_reload_ranking_models_test.json"
+	defer os.Remove(modelFile)
+
+	writeModelFile(t, modelFile, `{"features": [{"name": "price", "weight": 1}], "bias": 0}`)
+
+	tenants := registry.NewTenantSorterRegistry()
+	cfg := config.NewConfig()
+	cfg.RankingModels = []config.RankingModelConfig{{Name: "Learned Ranking", ModelFile: modelFile}}
+
+	if err := sorter.InitializeTenantSorters(tenants, cfg, sorter.DefaultSorterFactories()); err != nil {
+		t.Fatalf("InitializeTenantSorters failed: %v", err)
+	}
+
+	s, exists := tenants.Global().GetSorter("learned_ranking")
+	if !exists {
+		t.Fatal("Learned Ranking was not registered")
+	}
+	products := createRankingTestProducts()
+	assertIDs(t, s.Sort(products), 2, 3, 1)
+
+	writeModelFile(t, modelFile, `{"features": [{"name": "price", "weight": -1}], "bias": 0}`)
+	if err := sorter.ReloadRankingModels(tenants.Global(), cfg); err != nil {
+		t.Fatalf("ReloadRankingModels failed: %v", err)
+	}
+	assertIDs(t, s.Sort(products), 1, 3, 2)
+
+	writeModelFile(t, modelFile, `{"features": []}`)
+	if err := sorter.ReloadRankingModels(tenants.Global(), cfg); err == nil {
+		t.Error("ReloadRankingModels did not return error for an invalid model")
+	}
+	assertIDs(t, s.Sort(products), 1, 3, 2)
+}
+
+func TestInitializeDefaultSortersWithRankingModels(t *testing.T) {
+
+	modelFile := "temp_ranking_model_test.json"
+	defer os.Remove(modelFile)
+
+	writeModelFile(t, modelFile, `{"features": [{"name": "sales_per_view", "weight": 1}], "bias": 0}`)
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.RankingModels = []config.RankingModelConfig{{Name: "Learned Ranking", ModelFile: modelFile}}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	s, exists := reg.GetSorter("Learned Ranking")
+	if !exists {
+		t.Fatal("Learned Ranking was not registered")
+	}
+	assertIDs(t, s.Sort(createRankingTestProducts()), 2, 1, 3)
+
+	cfg.RankingModels = []config.RankingModelConfig{{Name: "Missing", ModelFile: "missing_model.json"}}
+	if err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg); err == nil {
+		t.Error("InitializeDefaultSorters did not return error for a missing model file")
+	}
+}
@@ -0,0 +1,122 @@
+package sorter_test
+
+import (
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/infrastructure/config"
+	"assessment/usecase"
+)
+
+func TestNaturalCompare(t *testing.T) {
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"Table 2", "Table 10", -1},
+		{"Table 10", "Table 2", 1},
+		{"Table 1a", "Table 2", -1},
+		{"Table 1", "Table 1a", -1},
+		{"Table 007", "Table 7", 1},
+		{"Table 7", "Table 7", 0},
+		{"Chair", "Table 1", -1},
+		{"v1.10", "v1.9", 1},
+	}
+
+	for _, tt := range tests {
+		got := sorter.NaturalCompare(tt.a, tt.b)
+		if (got < 0) != (tt.want < 0) || (got > 0) != (tt.want > 0) {
+			t.Errorf("NaturalCompare(%q, %q) = %d, want sign of %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNaturalNameSorter(t *testing.T) {
+
+	products := model.ProductList{
+		{ID: 1, Name: "Table 10"},
+		{ID: 2, Name: "Table 2"},
+		{ID: 3, Name: "Table 1a"},
+		{ID: 4, Name: "table 1"},
+	}
+
+	ascending := sorter.NewNaturalNameSorter(true, sorter.LowercaseNormalizer)
+	assertIDs(t, ascending.Sort(products), 4, 3, 2, 1)
+
+	descending := sorter.NewNaturalNameSorter(false, sorter.LowercaseNormalizer)
+	assertIDs(t, descending.Sort(products), 1, 2, 3, 4)
+
+	if ascending.Name() != "Name (natural, ascending)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", ascending.Name(), "Name (natural, ascending)")
+	}
+}
+
+func TestNaturalNameSorterNormalizationPipeline(t *testing.T) {
+
+	products := model.ProductList{
+		{ID: 1, Name: "The Zebra Table"},
+		{ID: 2, Name: "\"Bistro\" Table"},
+		{ID: 3, Name: "An Oak Table"},
+		{ID: 4, Name: "Apple Crate"},
+	}
+
+	s := sorter.NewNaturalNameSorter(true,
+		sorter.LowercaseNormalizer,
+		sorter.PunctuationNormalizer,
+		sorter.ArticleNormalizer(sorter.DefaultArticles...),
+	)
+
+	assertIDs(t, s.Sort(products), 4, 2, 3, 1)
+
+	if got := sorter.PunctuationNormalizer("Table, \"Oak\" - 2"); got != "Table Oak 2" {
+		t.Errorf("PunctuationNormalizer mismatch: got %q", got)
+	}
+
+	if _, err := sorter.NameNormalizerByName("reverse", nil); err == nil {
+		t.Error("NameNormalizerByName did not return error for unknown normalizer")
+	}
+}
+
+func TestNaturalNameSorterFromConfig(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.NaturalNameSort = config.NaturalNameSortConfig{
+		Enabled:     true,
+		Normalizers: []string{"lowercase", "strip_articles"},
+	}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+
+	found := false
+	for _, id := range sorterUseCase.GetAvailableSorters() {
+		if id == "natural_name:asc" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Natural name sorter missing from GetAvailableSorters")
+	}
+
+	cfg.NaturalNameSort.Normalizers = []string{"unknown"}
+	if err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg); err == nil {
+		t.Error("InitializeDefaultSorters did not return error for unknown normalizer")
+	}
+
+	cfg.NaturalNameSort.Enabled = false
+	reg = registry.NewSorterRegistry()
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+	if _, exists := reg.GetSorter("Name (natural, ascending)"); exists {
+		t.Error("Disabled natural name sorter was registered")
+	}
+}
@@ -0,0 +1,134 @@
+package sorter_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"sort"
+	"testing"
+
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+)
+
+func lessByPriceOnly(a, b *model.Product) bool {
+	return a.Price < b.Price
+}
+
+func TestSortStableMatchesSequentialSort(t *testing.T) {
+
+	previous := sorter.ParallelThreshold()
+	defer sorter.SetParallelThreshold(previous)
+	sorter.SetParallelThreshold(1)
+
+	for _, procs := range []int{2, 3, 8} {
+		oldProcs := runtime.GOMAXPROCS(procs)
+
+		for _, n := range []int{0, 1, 2, 7, 100, 1001} {
+			products := createRandomProducts(n+4, int64(n))[:n]
+
+			expected := make(model.ProductList, len(products))
+			copy(expected, products)
+			sort.SliceStable(expected, func(i, j int) bool { return lessByPriceOnly(expected[i], expected[j]) })
+
+			sorter.SortStable(products, lessByPriceOnly)
+
+			for i := range expected {
+				if products[i] != expected[i] {
+					t.Errorf("GOMAXPROCS=%d n=%d: parallel sort differs from sequential stable sort at %d", procs, n, i)
+					break
+				}
+			}
+		}
+
+		runtime.GOMAXPROCS(oldProcs)
+	}
+}
+
+func TestBuiltInSortersParallelOutput(t *testing.T) {
+
+	products := createRandomProducts(2000, 7)
+
+	sequential := sorter.NewSalesPerViewSorter(false).Sort(products)
+
+	previous := sorter.ParallelThreshold()
+	defer sorter.SetParallelThreshold(previous)
+	sorter.SetParallelThreshold(100)
+
+	parallel := sorter.NewSalesPerViewSorter(false).Sort(products)
+
+	for i := range sequential {
+		if sequential[i].ID != parallel[i].ID {
+			t.Fatalf("Parallel output differs from sequential output at %d", i)
+		}
+	}
+}
+
+func benchmarkSortStable(b *testing.B, threshold int) {
+
+	previous := sorter.ParallelThreshold()
+	defer sorter.SetParallelThreshold(previous)
+	sorter.SetParallelThreshold(threshold)
+
+	products := createRandomProducts(500000, 1)
+	s := sorter.NewPriceSorter(true)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Sort(products)
+	}
+}
+
+func BenchmarkPriceSorterSequential(b *testing.B) {
+	benchmarkSortStable(b, int(^uint(0)>>1))
+}
+
+func BenchmarkPriceSorterParallel(b *testing.B) {
+	benchmarkSortStable(b, sorter.DefaultParallelThreshold)
+}
+
+func TestSortContextStopsOnceContextIsDone(t *testing.T) {
+
+	previous := sorter.ParallelThreshold()
+	defer sorter.SetParallelThreshold(previous)
+	sorter.SetParallelThreshold(1)
+
+	oldProcs := runtime.GOMAXPROCS(4)
+	defer runtime.GOMAXPROCS(oldProcs)
+
+	products := createRandomProducts(1000, 3)
+
+	// Canceling from the comparison leaves the chunks sorted and stops the
+	// sort before it merges them.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	canceling := sorter.NewFieldSorterFunc("Canceling", func(p *model.Product) float64 { return p.Price },
+		func(a, b float64) int {
+			cancel()
+			return cmp.Compare(a, b)
+		}, true)
+
+	if _, err := canceling.SortContext(ctx, products); !errors.Is(err, context.Canceled) {
+		t.Errorf("FieldSorter did not stop once canceled: got %v", err)
+	}
+
+	composite, _ := sorter.NewCompositeSorter("", sorter.SortKey{Field: "price", Ascending: true})
+	if _, err := composite.SortContext(ctx, products); !errors.Is(err, context.Canceled) {
+		t.Errorf("CompositeSorter did not stop once canceled: got %v", err)
+	}
+
+	for _, s := range []interface {
+		Sort(model.ProductList) model.ProductList
+		SortContext(context.Context, model.ProductList) (model.ProductList, error)
+	}{sorter.NewPriceSorter(true), composite} {
+		sorted, err := s.SortContext(context.Background(), products)
+		if err != nil {
+			t.Fatalf("SortContext failed: %v", err)
+		}
+		if !slices.Equal(productIDs(sorted), productIDs(s.Sort(products))) {
+			t.Error("SortContext order differs from Sort")
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package sorter_test
+
+import (
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/infrastructure/config"
+)
+
+func createScoreTestProducts() model.ProductList {
+
+	date, _ := time.Parse("2006-01-02", "2020-01-01")
+
+	return model.ProductList{
+		{ID: 1, Name: "Cheap Unpopular", Price: 5.0, Created: date, SalesCount: 1, ViewsCount: 100},
+		{ID: 2, Name: "Cheap Popular", Price: 6.0, Created: date, SalesCount: 900, ViewsCount: 1000},
+		{ID: 3, Name: "Pricey Popular", Price: 100.0, Created: date, SalesCount: 1000, ViewsCount: 1000},
+	}
+}
+
+func TestScoreSorter(t *testing.T) {
+
+	products := createScoreTestProducts()
+
+	s, err := sorter.NewScoreSorter("Popular but Cheap", false,
+		sorter.ScoreComponent{Signal: "sales", Weight: 1, Normalization: sorter.NormalizeMinMax},
+		sorter.ScoreComponent{Signal: "price", Weight: -1, Normalization: sorter.NormalizeMinMax},
+	)
+	if err != nil {
+		t.Fatalf("NewScoreSorter failed: %v", err)
+	}
+
+	assertIDs(t, s.Sort(products), 2, 1, 3)
+
+	if s.Name() != "Popular but Cheap" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", s.Name(), "Popular but Cheap")
+	}
+
+	ascending, err := sorter.NewScoreSorter("Least popular", true,
+		sorter.ScoreComponent{Signal: "views", Weight: 1, Normalization: sorter.NormalizeLog},
+	)
+	if err != nil {
+		t.Fatalf("NewScoreSorter failed: %v", err)
+	}
+
+	assertIDs(t, ascending.Sort(products), 1, 2, 3)
+}
+
+func TestScoreSorterZScoreNormalization(t *testing.T) {
+
+	products := createScoreTestProducts()
+
+	s, err := sorter.NewScoreSorter("Balanced", false,
+		sorter.ScoreComponent{Signal: "sales_per_view", Weight: 1, Normalization: sorter.NormalizeZScore},
+		sorter.ScoreComponent{Signal: "age", Weight: 1, Normalization: sorter.NormalizeZScore},
+	)
+	if err != nil {
+		t.Fatalf("NewScoreSorter failed: %v", err)
+	}
+
+	assertIDs(t, s.Sort(products), 3, 2, 1)
+
+	components := s.Components()
+	if components[0].Signal != "sales_per_view" || components[1].Normalization != sorter.NormalizeZScore {
+		t.Errorf("Components mismatch: got %v", components)
+	}
+}
+
+func TestScoreSorterInvalidComponents(t *testing.T) {
+
+	if _, err := sorter.NewScoreSorter("", false, sorter.ScoreComponent{Signal: "price"}); err == nil {
+		t.Error("NewScoreSorter did not return error for empty name")
+	}
+
+	if _, err := sorter.NewScoreSorter("Empty", false); err == nil {
+		t.Error("NewScoreSorter did not return error for no components")
+	}
+
+	if _, err := sorter.NewScoreSorter("Bad", false, sorter.ScoreComponent{Signal: "rating"}); err == nil {
+		t.Error("NewScoreSorter did not return error for unknown signal")
+	}
+
+	if _, err := sorter.NewScoreSorter("Bad", false, sorter.ScoreComponent{Signal: "price", Normalization: "cube"}); err == nil {
+		t.Error("NewScoreSorter did not return error for unknown normalization")
+	}
+}
+
+func TestInitializeDefaultSortersWithScoreProfiles(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.ScoreProfiles = []config.ScoreProfileConfig{
+		{
+			Name: "Popular but Cheap",
+			Components: []config.ScoreComponentConfig{
+				{Signal: "sales", Weight: 1, Normalization: "min_max"},
+				{Signal: "price", Weight: -1, Normalization: "min_max"},
+			},
+		},
+		{
+			Name:      "Cheapest",
+			Ascending: true,
+			Components: []config.ScoreComponentConfig{
+				{Signal: "price", Weight: 1},
+			},
+		},
+	}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	popular, exists := reg.GetSorter("Popular but Cheap")
+	if !exists {
+		t.Fatal("Score profile Popular but Cheap was not registered")
+	}
+	assertIDs(t, popular.Sort(createScoreTestProducts()), 2, 1, 3)
+
+	if _, exists := reg.GetSorter("Cheapest"); !exists {
+		t.Error("Score profile Cheapest was not registered")
+	}
+
+	cfg.ScoreProfiles = []config.ScoreProfileConfig{{Name: "Broken"}}
+	if err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg); err == nil {
+		t.Error("InitializeDefaultSorters did not return error for invalid score profile")
+	}
+}
@@ -0,0 +1,125 @@
+package sorter_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/infrastructure/config"
+)
+
+func TestShuffleSorterIsReproducible(t *testing.T) {
+
+	products := createRandomProducts(200, 1)
+
+	first := productIDs(sorter.NewShuffleSorter(7).Sort(products))
+	second := productIDs(sorter.NewShuffleSorter(7).Sort(createRandomProducts(200, 2)))
+
+	if !slices.Equal(first, second) {
+		t.Error("Same seed produced different orders for differently ordered input")
+	}
+
+	if slices.Equal(first, productIDs(sorter.NewShuffleSorter(8).Sort(products))) {
+		t.Error("Different seeds produced the same order")
+	}
+
+	ids := slices.Clone(first)
+	slices.Sort(ids)
+	for i, id := range ids {
+		if id != i+1 {
+			t.Fatalf("Shuffle is not a permutation of the input: got %v", ids)
+		}
+	}
+}
+
+func TestShuffleSorterContextSeed(t *testing.T) {
+
+	products := createRandomProducts(100, 1)
+	shuffle := sorter.NewShuffleSorter(7)
+
+	ctx := sorter.WithShuffleSeed(context.Background(), sorter.SeedFromString("session-42"))
+
+	sorted, err := shuffle.SortContext(ctx, products)
+	if err != nil {
+		t.Fatalf("SortContext failed: %v", err)
+	}
+
+	want := productIDs(sorter.NewShuffleSorter(sorter.SeedFromString("session-42")).Sort(products))
+	if !slices.Equal(productIDs(sorted), want) {
+		t.Error("SortContext did not use the seed from the context")
+	}
+}
+
+func TestJitterSorterStaysWithinWindow(t *testing.T) {
+
+	products := createRandomProducts(500, 1)
+	base := sorter.NewPriceSorter(true)
+
+	jitter, err := sorter.NewJitterSorter(base, 3, 7)
+	if err != nil {
+		t.Fatalf("NewJitterSorter failed: %v", err)
+	}
+
+	if jitter.Name() != "Price (ascending) (jitter 3)" {
+		t.Errorf("Sorter name mismatch: got %s", jitter.Name())
+	}
+
+	basePositions := make(map[int]int)
+	for i, p := range base.Sort(products) {
+		basePositions[p.ID] = i
+	}
+
+	jittered := jitter.Sort(products)
+	if len(jittered) != len(products) {
+		t.Fatalf("Product count mismatch: got %d, want %d", len(jittered), len(products))
+	}
+
+	moved := false
+	for i, p := range jittered {
+		shift := basePositions[p.ID] - i
+		if shift >= 3 || shift <= -3 {
+			t.Fatalf("Product %d moved %d positions with a window of 3", p.ID, shift)
+		}
+		moved = moved || shift != 0
+	}
+
+	if !moved {
+		t.Error("Jitter did not perturb the order")
+	}
+
+	if !slices.Equal(productIDs(jittered), productIDs(jitter.Sort(products))) {
+		t.Error("Jitter is not reproducible")
+	}
+
+	if _, err := sorter.NewJitterSorter(base, 0, 7); err == nil {
+		t.Error("NewJitterSorter did not return error for an empty window")
+	}
+}
+
+func TestInitializeDefaultSortersRegistersShuffle(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.Shuffle = config.ShuffleConfig{
+		Seed:   7,
+		Jitter: []config.JitterConfig{{Sorter: "Price (ascending)", Window: 2}},
+	}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	for _, name := range []string{"Shuffle", "Price (ascending) (jitter 2)"} {
+		if _, exists := reg.GetSorter(name); !exists {
+			t.Errorf("%s was not registered", name)
+		}
+	}
+
+	cfg.Shuffle.Jitter = []config.JitterConfig{{Sorter: "Color (ascending)", Window: 2}}
+	if err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg); err == nil {
+		t.Error("InitializeDefaultSorters did not return error for jitter of an unknown sorter")
+	}
+}
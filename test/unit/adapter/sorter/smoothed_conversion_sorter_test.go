@@ -0,0 +1,84 @@
+package sorter_test
+
+import (
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/infrastructure/config"
+)
+
+func createConversionTestProducts() model.ProductList {
+
+	date, _ := time.Parse("2006-01-02", "2020-01-01")
+
+	return model.ProductList{
+		{ID: 1, Name: "Lucky Newcomer", Price: 10.0, Created: date, SalesCount: 1, ViewsCount: 1},
+		{ID: 2, Name: "Proven Seller", Price: 10.0, Created: date, SalesCount: 300, ViewsCount: 1000},
+		{ID: 3, Name: "Steady Seller", Price: 10.0, Created: date, SalesCount: 1000, ViewsCount: 20000},
+	}
+}
+
+func TestSmoothedConversionSorterFixedPrior(t *testing.T) {
+
+	products := createConversionTestProducts()
+
+	prior := sorter.ConversionPrior{Sales: 5, Views: 100}
+
+	descendingSorter := sorter.NewSmoothedConversionSorter(false, prior)
+	ascendingSorter := sorter.NewSmoothedConversionSorter(true, prior)
+
+	sortedDescending := descendingSorter.Sort(products)
+	assertIDs(t, sortedDescending, 2, 1, 3)
+
+	sortedAscending := ascendingSorter.Sort(products)
+	assertIDs(t, sortedAscending, 3, 1, 2)
+
+	unsmoothed := sorter.NewSalesPerViewSorter(false).Sort(products)
+	if unsmoothed[0].ID != 1 {
+		t.Errorf("Expected raw sales per view to rank the low-traffic product first, got %d", unsmoothed[0].ID)
+	}
+
+	if descendingSorter.Name() != "Smoothed Conversion (descending)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", descendingSorter.Name(), "Smoothed Conversion (descending)")
+	}
+
+	if ascendingSorter.Name() != "Smoothed Conversion (ascending)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", ascendingSorter.Name(), "Smoothed Conversion (ascending)")
+	}
+}
+
+func TestSmoothedConversionSorterCatalogAveragePrior(t *testing.T) {
+
+	products := createConversionTestProducts()
+
+	s := sorter.NewSmoothedConversionSorter(false, sorter.ConversionPrior{Views: 1000, UseCatalogAverage: true})
+
+	assertIDs(t, s.Sort(products), 2, 1, 3)
+
+	noViews := model.ProductList{{ID: 1}, {ID: 2}}
+	assertIDs(t, s.Sort(noViews), 1, 2)
+}
+
+func TestInitializeDefaultSortersRegistersSmoothedConversion(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.SmoothedConversion = config.SmoothedConversionConfig{PriorSales: 5, PriorViews: 100}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	for _, name := range []string{"Smoothed Conversion (ascending)", "Smoothed Conversion (descending)"} {
+		if _, exists := reg.GetSorter(name); !exists {
+			t.Errorf("%s was not registered", name)
+		}
+	}
+
+	s, _ := reg.GetSorter("Smoothed Conversion (descending)")
+	assertIDs(t, s.Sort(createConversionTestProducts()), 2, 1, 3)
+}
@@ -0,0 +1,82 @@
+package sorter_test
+
+import (
+	"errors"
+	"testing"
+
+	"assessment/adapter/sorter"
+)
+
+func TestParseSortExpression(t *testing.T) {
+
+	composite, err := sorter.ParseSortExpression("price:asc, spv:desc,name")
+	if err != nil {
+		t.Fatalf("ParseSortExpression failed: %v", err)
+	}
+
+	keys := composite.Keys()
+	want := []sorter.SortKey{
+		{Field: "price", Ascending: true},
+		{Field: "sales_per_view", Ascending: false},
+		{Field: "name", Ascending: true},
+	}
+
+	if len(keys) != len(want) {
+		t.Fatalf("Sort key count mismatch: got %d, want %d", len(keys), len(want))
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("Sort key %d mismatch: got %v, want %v", i, keys[i], want[i])
+		}
+	}
+
+	assertIDs(t, composite.Sort(createTiedProducts()), 3, 2, 4, 1)
+}
+
+func TestParseSortExpressionErrors(t *testing.T) {
+
+	tests := []struct {
+		expression string
+		token      string
+		position   int
+	}{
+		{"", "", 0},
+		{"price,,name", "", 6},
+		{"price:asc,color:desc", "color", 10},
+		{"price:asc, created:sideways", "sideways", 19},
+		{"price,name,price:desc", "price", 11},
+	}
+
+	for _, tt := range tests {
+		_, err := sorter.ParseSortExpression(tt.expression)
+		if err == nil {
+			t.Errorf("ParseSortExpression(%q) did not return error", tt.expression)
+			continue
+		}
+
+		var exprErr *sorter.ExpressionError
+		if !errors.As(err, &exprErr) {
+			t.Errorf("ParseSortExpression(%q) returned %T, want *ExpressionError", tt.expression, err)
+			continue
+		}
+
+		if exprErr.Token != tt.token || exprErr.Position != tt.position {
+			t.Errorf("ParseSortExpression(%q) error points to %q at %d, want %q at %d",
+				tt.expression, exprErr.Token, exprErr.Position, tt.token, tt.position)
+		}
+	}
+}
+
+func TestExpressionParser(t *testing.T) {
+
+	parser := sorter.NewExpressionParser()
+
+	s, err := parser.Parse("created:desc")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if s.Name() != "Creation Date (descending)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", s.Name(), "Creation Date (descending)")
+	}
+}
@@ -0,0 +1,212 @@
+package sorter_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/domain/service"
+	"assessment/infrastructure/config"
+)
+
+func TestDefaultSorterFactoriesBuildFromConfig(t *testing.T) {
+
+	factories := sorter.DefaultSorterFactories()
+
+	if got := factories.Types(); !slices.Equal(got, []string{"composite", "field", "score", "wilson"}) {
+		t.Errorf("Sorter types mismatch: got %v", got)
+	}
+
+	products := createConversionTestProducts()
+
+	tests := []struct {
+		config config.SorterConfig
+		id     string
+		first  int
+	}{
+		{
+			config: config.SorterConfig{Type: "field", ID: "best_sellers", Name: "Best Sellers", Params: json.RawMessage(`{"field": "sales", "ascending": false}`)},
+			id:     "best_sellers",
+			first:  3,
+		},
+		{
+			config: config.SorterConfig{Type: "Wilson", Name: "Most Reliably Popular", Params: json.RawMessage(`{"confidence_level": 0.99}`)},
+			id:     "most_reliably_popular",
+			first:  2,
+		},
+		{
+			config: config.SorterConfig{Type: "score", Name: "Most Viewed", Params: json.RawMessage(`{"components": [{"signal": "views", "weight": 1}]}`)},
+			id:     "most_viewed",
+			first:  3,
+		},
+		{
+			config: config.SorterConfig{Type: "composite", Name: "Cheap then new", Params: json.RawMessage(`{"keys": [{"field": "price", "ascending": true}, {"field": "created"}]}`)},
+			id:     "cheap_then_new",
+			first:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		s, err := factories.Build(tt.config)
+		if err != nil {
+			t.Errorf("Build(%s) failed: %v", tt.config.Type, err)
+			continue
+		}
+
+		if service.SorterID(s) != tt.id {
+			t.Errorf("%s sorter ID mismatch: got %s, want %s", tt.config.Type, service.SorterID(s), tt.id)
+		}
+
+		if sorted := s.Sort(products); sorted[0].ID != tt.first {
+			t.Errorf("%s sorter ranked %d first, want %d", tt.config.Type, sorted[0].ID, tt.first)
+		}
+	}
+}
+
+func TestFieldSorterFactoryBuildsFieldSorters(t *testing.T) {
+
+	factories := sorter.DefaultSorterFactories()
+
+	price, err := factories.Build(config.SorterConfig{Type: "field", Params: json.RawMessage(`{"field": "price", "ascending": true}`)})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if _, ok := price.(*sorter.PriceSorter); !ok || service.SorterID(price) != "price:asc" {
+		t.Errorf("Unnamed price field sorter is not the built-in one: %T %s", price, service.SorterID(price))
+	}
+
+	sales, err := factories.Build(config.SorterConfig{Type: "field", Params: json.RawMessage(`{"field": "sales_count"}`)})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if _, ok := sales.(*sorter.FieldSorter[int]); !ok || service.SorterID(sales) != "sales:desc" || sales.Name() != "Sales (descending)" {
+		t.Errorf("Sales field sorter mismatch: %T %s %q", sales, service.SorterID(sales), sales.Name())
+	}
+	if _, ok := sales.(service.TopKSorter); !ok {
+		t.Error("Field sorter does not support partial sorting")
+	}
+
+	named, err := factories.Build(config.SorterConfig{Type: "field", Name: "Oldest", Params: json.RawMessage(`{"field": "creation_date", "ascending": true}`)})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if service.SorterID(named) != "oldest" || slices.Contains(service.SorterAliases(named), "creation_date:asc") {
+		t.Errorf("Named field sorter kept the built-in identity: %s %v", service.SorterID(named), service.SorterAliases(named))
+	}
+	if explanations := service.Explain(named, createConversionTestProducts()); explanations[0].Key == nil {
+		t.Error("Named field sorter does not explain its key")
+	}
+}
+
+func TestInitializeSortersBuildsLegacySectionsWithFactories(t *testing.T) {
+
+	factories := sorter.DefaultSorterFactories()
+
+	cfg := config.NewConfig()
+	cfg.CompositeSorters = []config.CompositeSorterConfig{{Name: "Cheap then new", Keys: []config.SortKeyConfig{{Field: "price", Ascending: true}, {Field: "created"}}}}
+	cfg.ScoreProfiles = []config.ScoreProfileConfig{{ID: "most_viewed", Name: "Most Viewed", Components: []config.ScoreComponentConfig{{Signal: "views", Weight: 1}}}}
+
+	reg := registry.NewSorterRegistry()
+	if err := sorter.InitializeSorters(reg, cfg, factories); err != nil {
+		t.Fatalf("InitializeSorters failed: %v", err)
+	}
+	for _, id := range []string{"cheap_then_new", "most_viewed", "price:asc", "wilson_score:desc"} {
+		if _, exists := reg.GetSorter(id); !exists {
+			t.Errorf("%s was not registered", id)
+		}
+	}
+
+	cfg.CompositeSorters[0].Keys = []config.SortKeyConfig{{Field: "color"}}
+	err := sorter.InitializeSorters(registry.NewSorterRegistry(), cfg, factories)
+	if err == nil || !strings.Contains(err.Error(), "composite sorter 0 (composite)") {
+		t.Errorf("Expected the composite sorter error to name the section, got %v", err)
+	}
+}
+
+func TestSorterFactoryRegistryBuildErrors(t *testing.T) {
+
+	factories := sorter.DefaultSorterFactories()
+
+	invalid := []config.SorterConfig{
+		{Type: "unknown", Name: "Unknown"},
+		{Type: "field", Name: "No Field"},
+		{Type: "field", Name: "Typo", Params: json.RawMessage(`{"feild": "price"}`)},
+		{Type: "score", Name: "No Components"},
+		{Type: "wilson", Name: "Overconfident", Params: json.RawMessage(`{"confidence_level": 1.5}`)},
+		{Type: "composite", Name: "Unknown Field", Params: json.RawMessage(`{"keys": [{"field": "color"}]}`)},
+	}
+
+	for _, c := range invalid {
+		if _, err := factories.Build(c); err == nil {
+			t.Errorf("Build did not return error for %s sorter %q", c.Type, c.Name)
+		}
+	}
+}
+
+func TestSorterFactoryRegistryRegisterCustomType(t *testing.T) {
+
+	factories := sorter.DefaultSorterFactories()
+
+	err := factories.Register("id", func(c config.SorterConfig) (service.Sorter, error) {
+		return sorter.NewFieldSorter(c.Name, func(p *model.Product) int { return p.ID }, false), nil
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	if err := factories.Register("Field", nil); err == nil {
+		t.Error("Register did not return error for a duplicate type")
+	}
+
+	reg := registry.NewSorterRegistry()
+	cfg := config.NewConfig()
+	cfg.Sorters = []config.SorterConfig{{Type: "id", ID: "newest_listing", Name: "Newest Listing", Description: "Most recently listed first"}}
+
+	if err := sorter.InitializeSorters(reg, cfg, factories); err != nil {
+		t.Fatalf("InitializeSorters failed: %v", err)
+	}
+
+	s, exists := reg.GetSorter("newest_listing")
+	if !exists {
+		t.Fatal("Sorter from config was not registered")
+	}
+
+	assertIDs(t, s.Sort(createConversionTestProducts()), 3, 2, 1)
+
+	if got := service.DescribeSorter(s).Description; got != "Most recently listed first" {
+		t.Errorf("Description mismatch: got %q", got)
+	}
+}
+
+func TestInitializeDefaultSortersWithConfiguredSorters(t *testing.T) {
+
+	cfg := config.NewConfig()
+	cfg.Sorters = []config.SorterConfig{
+		{Type: "field", ID: "best_sellers", Name: "Best Sellers", Params: json.RawMessage(`{"field": "sales"}`)},
+	}
+	cfg.Shuffle.Jitter = []config.JitterConfig{{Sorter: "best_sellers", Window: 2}}
+
+	reg := registry.NewSorterRegistry()
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	for _, id := range []string{"best_sellers", "best_sellers+jitter2"} {
+		if _, exists := reg.GetSorter(id); !exists {
+			t.Errorf("%s was not registered", id)
+		}
+	}
+
+	cfg.Sorters = append(cfg.Sorters, config.SorterConfig{Type: "field", Params: json.RawMessage(`{"field": "price", "ascending": true}`)})
+	cfg.Shuffle.Jitter = nil
+
+	err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg)
+	if !errors.Is(err, service.ErrSorterExists) || !strings.Contains(err.Error(), "sorter 1") {
+		t.Errorf("Expected the unnamed price sorter to conflict with price:asc, got %v", err)
+	}
+}
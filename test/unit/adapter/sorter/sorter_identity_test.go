@@ -0,0 +1,79 @@
+package sorter_test
+
+import (
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/service"
+	"assessment/infrastructure/config"
+)
+
+func TestBuiltInSorterIdentity(t *testing.T) {
+
+	collated, _ := sorter.NewCollatedNameSorter(false, "de-DE")
+	composite, _ := sorter.NewCompositeSorter("", sorter.SortKey{Field: "price"}, sorter.SortKey{Field: "date", Ascending: true})
+
+	tests := []struct {
+		sorter    service.IdentifiedSorter
+		id        string
+		direction service.SortDirection
+		label     string
+	}{
+		{sorter.NewPriceSorter(true), "price:asc", service.SortAscending, "Preis (aufsteigend)"},
+		{sorter.NewDateSorter(false), "created:desc", service.SortDescending, "Erstellungsdatum (absteigend)"},
+		{sorter.NewSalesPerViewSorter(false), "sales_per_view:desc", service.SortDescending, "Verkäufe pro Aufruf (absteigend)"},
+		{sorter.NewNaturalNameSorter(true), "natural_name:asc", service.SortAscending, "Name (natürlich, aufsteigend)"},
+		{collated, "name_de_de:desc", service.SortDescending, "Name (absteigend, de-DE)"},
+		{composite, "price:desc,created:asc", service.SortDescending, "Preis (absteigend), dann Erstellungsdatum (aufsteigend)"},
+		{sorter.NewShuffleSorter(1), "shuffle", "", "Zufällig"},
+	}
+
+	for _, tt := range tests {
+		if tt.sorter.ID() != tt.id {
+			t.Errorf("ID mismatch for %s: got %s, want %s", tt.sorter.Name(), tt.sorter.ID(), tt.id)
+		}
+		if tt.sorter.Direction() != tt.direction {
+			t.Errorf("Direction mismatch for %s: got %q, want %q", tt.sorter.Name(), tt.sorter.Direction(), tt.direction)
+		}
+		if label := tt.sorter.Label("de-DE"); label != tt.label {
+			t.Errorf("German label mismatch for %s: got %s, want %s", tt.sorter.Name(), label, tt.label)
+		}
+		if label := tt.sorter.Label("en"); label != tt.sorter.Name() {
+			t.Errorf("English label %q does not match name %q", label, tt.sorter.Name())
+		}
+	}
+}
+
+func TestInitializeDefaultSortersUniqueIDs(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.NameLocales = []string{"sv-SE"}
+	cfg.NaturalNameSort.Enabled = true
+	cfg.ScoreProfiles = []config.ScoreProfileConfig{
+		{Name: "Popular but Cheap", Components: []config.ScoreComponentConfig{{Signal: "sales", Weight: 1}}},
+		{ID: "custom", Name: "Custom Score", Components: []config.ScoreComponentConfig{{Signal: "views", Weight: 1}}},
+	}
+	cfg.Shuffle.Jitter = []config.JitterConfig{{Sorter: "price:asc", Window: 2}}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range reg.GetAllSorters() {
+		id := service.SorterID(s)
+		if seen[id] {
+			t.Errorf("Duplicate sorter ID: %s", id)
+		}
+		seen[id] = true
+	}
+
+	for _, id := range []string{"popular_but_cheap", "custom", "price:asc+jitter2", "name_sv_se:asc", "trending"} {
+		if !seen[id] {
+			t.Errorf("Sorter %s was not registered", id)
+		}
+	}
+}
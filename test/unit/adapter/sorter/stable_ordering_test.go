@@ -0,0 +1,60 @@
+package sorter_test
+
+import (
+	"testing"
+	"time"
+
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+func createIdenticalProducts() model.ProductList {
+
+	date, _ := time.Parse("2006-01-02", "2020-01-01")
+
+	products := make(model.ProductList, 0, 20)
+	for id := 20; id >= 1; id-- {
+		products = append(products, &model.Product{
+			ID:         id,
+			Name:       "Same Product",
+			Price:      9.99,
+			Created:    date,
+			SalesCount: 10,
+			ViewsCount: 100,
+		})
+	}
+	return products
+}
+
+func TestBuiltInSortersBreakTiesByID(t *testing.T) {
+
+	composite, err := sorter.NewCompositeSorter("", sorter.SortKey{Field: "price", Ascending: false})
+	if err != nil {
+		t.Fatalf("NewCompositeSorter failed: %v", err)
+	}
+
+	sorters := []service.Sorter{
+		sorter.NewPriceSorter(true),
+		sorter.NewPriceSorter(false),
+		sorter.NewDateSorter(true),
+		sorter.NewDateSorter(false),
+		sorter.NewNameSorter(true),
+		sorter.NewNameSorter(false),
+		sorter.NewSalesPerViewSorter(true),
+		sorter.NewSalesPerViewSorter(false),
+		composite,
+	}
+
+	products := createIdenticalProducts()
+
+	for _, s := range sorters {
+		sorted := s.Sort(products)
+		for i := 0; i < len(sorted); i++ {
+			if sorted[i].ID != i+1 {
+				t.Errorf("%s: ties not broken by ID: got %v", s.Name(), productIDs(sorted))
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package sorter_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+func createRandomProducts(n int, seed int64) model.ProductList {
+
+	rng := rand.New(rand.NewSource(seed))
+	base, _ := time.Parse("2006-01-02", "2015-01-01")
+
+	products := make(model.ProductList, n)
+	for i := range products {
+		products[i] = &model.Product{
+			ID:         i + 1,
+			Name:       fmt.Sprintf("Product %d", rng.Intn(n/4+1)),
+			Price:      float64(rng.Intn(100)),
+			Created:    base.AddDate(0, 0, rng.Intn(3000)),
+			SalesCount: rng.Intn(500),
+			ViewsCount: rng.Intn(5000),
+		}
+	}
+	rng.Shuffle(len(products), func(i, j int) { products[i], products[j] = products[j], products[i] })
+
+	return products
+}
+
+func TestSortTopKMatchesFullSort(t *testing.T) {
+
+	composite, _ := sorter.NewCompositeSorter("", sorter.SortKey{Field: "price"}, sorter.SortKey{Field: "name", Ascending: true})
+	wilson, _ := sorter.NewWilsonScoreSorter(false, 0.95)
+	trending, _ := sorter.NewTrendingSorter(sorter.DefaultTrendingHalfLife, fixedClock("2024-01-01"))
+	score, _ := sorter.NewScoreSorter("Score", false, sorter.ScoreComponent{Signal: "sales", Weight: 1, Normalization: sorter.NormalizeZScore})
+
+	sorters := []service.TopKSorter{
+		sorter.NewPriceSorter(true),
+		sorter.NewPriceSorter(false),
+		sorter.NewDateSorter(false),
+		sorter.NewNameSorter(true),
+		sorter.NewSalesPerViewSorter(false),
+		sorter.NewSmoothedConversionSorter(false, sorter.ConversionPrior{Views: 100, UseCatalogAverage: true}),
+		wilson,
+		trending,
+		score,
+		composite,
+	}
+
+	products := createRandomProducts(500, 42)
+
+	for _, s := range sorters {
+		full := s.Sort(products)
+
+		for _, k := range []int{0, 1, 10, 137, 500, 1000} {
+			top := s.SortTopK(products, k)
+
+			want := min(k, len(products))
+			if len(top) != want {
+				t.Errorf("%s: SortTopK(%d) returned %d products, want %d", s.Name(), k, len(top), want)
+				continue
+			}
+
+			for i := range top {
+				if top[i].ID != full[i].ID {
+					t.Errorf("%s: SortTopK(%d) differs from full sort at %d: got %d, want %d",
+						s.Name(), k, i, top[i].ID, full[i].ID)
+					break
+				}
+				if top[i] == full[i] {
+					t.Errorf("%s: SortTopK(%d) did not clone the returned products", s.Name(), k)
+					break
+				}
+			}
+		}
+	}
+}
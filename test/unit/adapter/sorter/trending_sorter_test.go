@@ -0,0 +1,84 @@
+package sorter_test
+
+import (
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/infrastructure/config"
+)
+
+func fixedClock(date string) sorter.Clock {
+	now, _ := time.Parse("2006-01-02", date)
+	return sorter.ClockFunc(func() time.Time { return now })
+}
+
+func TestTrendingSorter(t *testing.T) {
+
+	launched, _ := time.Parse("2006-01-02", "2024-05-20")
+	established, _ := time.Parse("2006-01-02", "2024-03-01")
+	ancient, _ := time.Parse("2006-01-02", "2015-01-01")
+
+	products := model.ProductList{
+		{ID: 1, Name: "Old Bestseller", Created: ancient, SalesCount: 50000, ViewsCount: 500000},
+		{ID: 2, Name: "New Hit", Created: launched, SalesCount: 120, ViewsCount: 600},
+		{ID: 3, Name: "Established", Created: established, SalesCount: 3000, ViewsCount: 40000},
+		{ID: 4, Name: "New Flop", Created: launched, SalesCount: 1, ViewsCount: 600},
+	}
+
+	s, err := sorter.NewTrendingSorter(30*24*time.Hour, fixedClock("2024-06-01"))
+	if err != nil {
+		t.Fatalf("NewTrendingSorter failed: %v", err)
+	}
+
+	assertIDs(t, s.Sort(products), 2, 3, 4, 1)
+
+	if s.Name() != "Trending" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", s.Name(), "Trending")
+	}
+
+	later, err := sorter.NewTrendingSorter(30*24*time.Hour, fixedClock("2025-06-01"))
+	if err != nil {
+		t.Fatalf("NewTrendingSorter failed: %v", err)
+	}
+
+	sorted := later.Sort(products)
+	if sorted[0].ID != 3 {
+		t.Errorf("Expected the new hit to decay below the established product a year later, got %v", productIDs(sorted))
+	}
+}
+
+func TestTrendingSorterInvalidHalfLife(t *testing.T) {
+
+	if _, err := sorter.NewTrendingSorter(0, nil); err == nil {
+		t.Error("NewTrendingSorter did not return error for zero half-life")
+	}
+
+	cfg := config.NewConfig()
+	cfg.Trending.HalfLifeDays = -1
+
+	if err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg); err == nil {
+		t.Error("InitializeDefaultSorters did not return error for invalid trending half-life")
+	}
+}
+
+func TestScoreSorterClock(t *testing.T) {
+
+	older, _ := time.Parse("2006-01-02", "2020-01-01")
+	newer, _ := time.Parse("2006-01-02", "2024-01-01")
+
+	products := model.ProductList{
+		{ID: 1, Created: older},
+		{ID: 2, Created: newer},
+	}
+
+	s, err := sorter.NewScoreSorter("Newest", true, sorter.ScoreComponent{Signal: "age", Weight: 1, Normalization: sorter.NormalizeLog})
+	if err != nil {
+		t.Fatalf("NewScoreSorter failed: %v", err)
+	}
+	s.SetClock(fixedClock("2024-01-01"))
+
+	assertIDs(t, s.Sort(products), 2, 1)
+}
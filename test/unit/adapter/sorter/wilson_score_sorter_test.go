@@ -0,0 +1,83 @@
+package sorter_test
+
+import (
+	"math"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/infrastructure/config"
+)
+
+func TestWilsonScoreSorter(t *testing.T) {
+
+	products := createConversionTestProducts()
+
+	descendingSorter, err := sorter.NewWilsonScoreSorter(false, 0.95)
+	if err != nil {
+		t.Fatalf("NewWilsonScoreSorter failed: %v", err)
+	}
+
+	ascendingSorter, err := sorter.NewWilsonScoreSorter(true, 0.95)
+	if err != nil {
+		t.Fatalf("NewWilsonScoreSorter failed: %v", err)
+	}
+
+	assertIDs(t, descendingSorter.Sort(products), 2, 1, 3)
+	assertIDs(t, ascendingSorter.Sort(products), 3, 1, 2)
+
+	if descendingSorter.Name() != "Wilson Score (descending)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", descendingSorter.Name(), "Wilson Score (descending)")
+	}
+
+	if ascendingSorter.Name() != "Wilson Score (ascending)" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", ascendingSorter.Name(), "Wilson Score (ascending)")
+	}
+}
+
+func TestWilsonScoreInterval(t *testing.T) {
+
+	s, err := sorter.NewWilsonScoreSorter(false, 0.95)
+	if err != nil {
+		t.Fatalf("NewWilsonScoreSorter failed: %v", err)
+	}
+
+	interval := s.Interval(&model.Product{SalesCount: 1, ViewsCount: 1})
+	if math.Abs(interval.Lower-0.2065) > 0.0001 {
+		t.Errorf("Lower bound mismatch: got %f, want %f", interval.Lower, 0.2065)
+	}
+	if interval.Upper != 1 {
+		t.Errorf("Upper bound mismatch: got %f, want %f", interval.Upper, 1.0)
+	}
+
+	interval = s.Interval(&model.Product{SalesCount: 50, ViewsCount: 1000})
+	if !(interval.Lower < 0.05 && 0.05 < interval.Upper) {
+		t.Errorf("Interval %s does not contain the observed rate", interval)
+	}
+
+	if zero := s.Interval(&model.Product{SalesCount: 5}); zero != (sorter.WilsonInterval{}) {
+		t.Errorf("Interval for zero views should be empty, got %v", zero)
+	}
+
+	wider, _ := sorter.NewWilsonScoreSorter(false, 0.99)
+	if wider.Interval(&model.Product{SalesCount: 50, ViewsCount: 1000}).Lower >= interval.Lower {
+		t.Error("Higher confidence level did not widen the interval")
+	}
+}
+
+func TestWilsonScoreSorterInvalidConfidence(t *testing.T) {
+
+	for _, confidence := range []float64{0, 1, -0.5, 1.5} {
+		if _, err := sorter.NewWilsonScoreSorter(true, confidence); err == nil {
+			t.Errorf("NewWilsonScoreSorter did not return error for confidence %v", confidence)
+		}
+	}
+
+	cfg := config.NewConfig()
+	cfg.WilsonScore.ConfidenceLevel = 2
+
+	if err := sorter.InitializeDefaultSorters(registry.NewSorterRegistry(), cfg); err == nil {
+		t.Error("InitializeDefaultSorters did not return error for invalid confidence level")
+	}
+}
@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+func createMerchandisingTestProducts(now time.Time) model.ProductList {
+	old := now.AddDate(-1, 0, 0)
+
+	return model.ProductList{
+		{ID: 1, Created: old, ViewsCount: 100},
+		{ID: 2, Created: old, ViewsCount: 0},
+		{ID: 3, Created: old, ViewsCount: 100},
+		{ID: 4, Created: old, ViewsCount: 0},
+		{ID: 5, Created: old, ViewsCount: 100},
+		{ID: 6, Created: now.AddDate(0, 0, -3), ViewsCount: 100},
+	}
+}
+
+func merchandisedIDs(products model.ProductList) []int {
+	ids := make([]int, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func assertMerchandisedIDs(t *testing.T, products model.ProductList, want []int) {
+	t.Helper()
+
+	got := merchandisedIDs(products)
+	if len(got) != len(want) {
+		t.Fatalf("Product count mismatch: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Product order mismatch: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestApplyMerchandising(t *testing.T) {
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := createMerchandisingTestProducts(now)
+
+	rules := []service.MerchandisingRule{
+		{Type: service.RulePin, ProductID: 5, Position: 1},
+		{Type: service.RuleBoost, CreatedWithin: 30 * 24 * time.Hour, Positions: 2},
+		{Type: service.RuleBury, ZeroViews: true},
+	}
+
+	merchandised := service.ApplyMerchandising(products, rules, now)
+
+	assertMerchandisedIDs(t, merchandised, []int{5, 1, 6, 3, 2, 4})
+	assertMerchandisedIDs(t, products, []int{1, 2, 3, 4, 5, 6})
+}
+
+func TestApplyMerchandisingPinBeyondEnd(t *testing.T) {
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := createMerchandisingTestProducts(now)
+
+	rules := []service.MerchandisingRule{
+		{Type: service.RulePin, ProductID: 1, Position: 100},
+		{Type: service.RulePin, ProductID: 42, Position: 1},
+	}
+
+	merchandised := service.ApplyMerchandising(products, rules, now)
+
+	assertMerchandisedIDs(t, merchandised, []int{2, 3, 4, 5, 6, 1})
+}
+
+func TestApplyMerchandisingAdjacentBoosts(t *testing.T) {
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-1, 0, 0)
+	recent := now.AddDate(0, 0, -3)
+
+	products := model.ProductList{
+		{ID: 1, Created: old},
+		{ID: 2, Created: old},
+		{ID: 3, Created: recent},
+		{ID: 4, Created: recent},
+		{ID: 5, Created: old},
+		{ID: 6, Created: recent},
+		{ID: 7, Created: recent},
+	}
+
+	oneUp := []service.MerchandisingRule{{Type: service.RuleBoost, CreatedWithin: 30 * 24 * time.Hour, Positions: 1}}
+	assertMerchandisedIDs(t, service.ApplyMerchandising(products[:4], oneUp, now), []int{1, 3, 4, 2})
+	assertMerchandisedIDs(t, service.ApplyMerchandising(products, oneUp, now), []int{1, 3, 4, 2, 6, 7, 5})
+
+	twoUp := []service.MerchandisingRule{{Type: service.RuleBoost, CreatedWithin: 30 * 24 * time.Hour, Positions: 2}}
+	assertMerchandisedIDs(t, service.ApplyMerchandising(products, twoUp, now), []int{3, 4, 1, 6, 7, 2, 5})
+}
+
+func TestApplyMerchandisingPinSelectors(t *testing.T) {
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := createMerchandisingTestProducts(now)
+
+	rules := []service.MerchandisingRule{
+		{Type: service.RulePin, ProductID: 3, ZeroViews: true, Position: 1},
+		{Type: service.RulePin, ProductID: 4, ZeroViews: true, Position: 2},
+	}
+
+	assertMerchandisedIDs(t, service.ApplyMerchandising(products, rules, now), []int{1, 4, 2, 3, 5, 6})
+}
+
+func TestApplyMerchandisingSchedule(t *testing.T) {
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := createMerchandisingTestProducts(now)
+
+	rules := []service.MerchandisingRule{
+		{Type: service.RulePin, ProductID: 3, Position: 1, Start: now.AddDate(0, 0, 1)},
+		{Type: service.RulePin, ProductID: 4, Position: 1, End: now},
+		{Type: service.RulePin, ProductID: 6, Position: 1, Start: now.AddDate(0, 0, -1), End: now.AddDate(0, 0, 1)},
+	}
+
+	merchandised := service.ApplyMerchandising(products, rules, now)
+
+	assertMerchandisedIDs(t, merchandised, []int{6, 1, 2, 3, 4, 5})
+}
+
+func TestMerchandisingRuleValidate(t *testing.T) {
+
+	now := time.Now()
+
+	invalid := []service.MerchandisingRule{
+		{Type: "promote", ProductID: 1},
+		{Type: service.RulePin, ProductID: 1},
+		{Type: service.RulePin, Position: 1},
+		{Type: service.RuleBoost, ProductID: 1},
+		{Type: service.RuleBoost, Positions: 3},
+		{Type: service.RuleBury},
+		{Type: service.RuleBury, ZeroViews: true, Start: now, End: now.Add(-time.Hour)},
+	}
+
+	for _, rule := range invalid {
+		if err := rule.Validate(); err == nil {
+			t.Errorf("Validate did not return error for %+v", rule)
+		}
+	}
+
+	valid := service.MerchandisingRule{Type: service.RuleBoost, ProductID: 1, Positions: 3}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate returned error for valid rule: %v", err)
+	}
+}
+
+func TestMerchandisedSorter(t *testing.T) {
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := createMerchandisingTestProducts(now)
+
+	inner := &LegacySorter{}
+	rules := []service.MerchandisingRule{{Type: service.RulePin, ProductID: 4, Position: 2}}
+	merchandised := service.NewMerchandisedSorter(inner, rules, func() time.Time { return now })
+
+	assertMerchandisedIDs(t, merchandised.Sort(products), []int{1, 4, 2, 3, 5, 6})
+
+	if merchandised.Name() != inner.Name() {
+		t.Errorf("Sorter name mismatch: got %s, want %s", merchandised.Name(), inner.Name())
+	}
+
+	if service.Unwrap(merchandised) != inner {
+		t.Error("Unwrap did not return the wrapped sorter")
+	}
+}
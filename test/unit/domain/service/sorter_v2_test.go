@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+)
+
+type LegacySorter struct {
+	calls int
+}
+
+func (s *LegacySorter) Sort(products model.ProductList) model.ProductList {
+	s.calls++
+	return products.Clone()
+}
+
+func (s *LegacySorter) Name() string {
+	return "Legacy"
+}
+
+type NativeSorter struct {
+	LegacySorter
+}
+
+func (s *NativeSorter) SortContext(_ context.Context, _ model.ProductList) (model.ProductList, error) {
+	return nil, errors.New("model unavailable")
+}
+
+func TestAsSorterV2AdaptsLegacySorter(t *testing.T) {
+
+	legacy := &LegacySorter{}
+	v2 := service.AsSorterV2(legacy)
+
+	products := model.ProductList{{ID: 1}, {ID: 2}}
+
+	sorted, err := v2.SortContext(context.Background(), products)
+	if err != nil {
+		t.Fatalf("SortContext failed: %v", err)
+	}
+
+	if len(sorted) != 2 || legacy.calls != 1 {
+		t.Errorf("Adapter did not delegate to the legacy sorter")
+	}
+
+	if v2.Name() != "Legacy" {
+		t.Errorf("Sorter name mismatch: got %s, want %s", v2.Name(), "Legacy")
+	}
+
+	if service.Unwrap(v2.(service.Sorter)) != legacy {
+		t.Error("Unwrap did not return the legacy sorter")
+	}
+}
+
+func TestAsSorterV2HonorsCancellation(t *testing.T) {
+
+	legacy := &LegacySorter{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := service.AsSorterV2(legacy).SortContext(ctx, model.ProductList{{ID: 1}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("SortContext error mismatch: got %v, want %v", err, context.Canceled)
+	}
+
+	if legacy.calls != 0 {
+		t.Error("Legacy sorter ran after the context was canceled")
+	}
+}
+
+func TestAsSorterV2KeepsNativeImplementation(t *testing.T) {
+
+	native := &NativeSorter{}
+
+	_, err := service.AsSorterV2(native).SortContext(context.Background(), model.ProductList{})
+	if err == nil || err.Error() != "model unavailable" {
+		t.Errorf("Native SortContext was not used: got %v", err)
+	}
+}
@@ -87,3 +87,148 @@ func TestConfigSaveToInvalidPath(t *testing.T) {
 		t.Error("SaveToFile did not return error for invalid path")
 	}
 }
+
+func TestConfigSmoothedConversionDefaults(t *testing.T) {
+
+	cfg := config.NewConfig()
+
+	if !cfg.SmoothedConversion.UseCatalogAverage {
+		t.Error("Default smoothed conversion prior should use the catalog average")
+	}
+
+	if cfg.SmoothedConversion.PriorViews != 100 {
+		t.Errorf("Default PriorViews mismatch: got %f, want %d", cfg.SmoothedConversion.PriorViews, 100)
+	}
+}
+
+func TestConfigLoadMerchandisingRulesFile(t *testing.T) {
+
+	rulesFile := "temp_merchandising_rules_test.json"
+	configFile := "temp_merchandising_config_test.json"
+	defer os.Remove(rulesFile)
+	defer os.Remove(configFile)
+
+	rules := `[{"type": "pin", "product_id": 42, "position": 1, "end": "2024-07-01T00:00:00Z"}]`
+	if err := os.WriteFile(rulesFile, []byte(rules), 0o600); err != nil {
+		t.Fatalf("Failed to write rules file: %v", err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.MerchandisingRules = []config.MerchandisingRuleConfig{{Type: "bury", ZeroViews: true}}
+	cfg.MerchandisingRulesFile = rulesFile
+	if err := cfg.SaveToFile(configFile); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	loadedCfg := config.NewConfig()
+	if err := loadedCfg.LoadFromFile(configFile); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	loaded := loadedCfg.AllMerchandisingRules()
+	if len(loaded) != 2 {
+		t.Fatalf("Merchandising rule count mismatch: got %d, want %d", len(loaded), 2)
+	}
+
+	if loaded[0].Type != "bury" || loaded[1].Type != "pin" || loaded[1].ProductID != 42 {
+		t.Errorf("Merchandising rules mismatch: got %+v", loaded)
+	}
+
+	if loaded[1].End == nil || loaded[1].End.Month() != 7 {
+		t.Errorf("Merchandising rule end time not loaded: got %v", loaded[1].End)
+	}
+
+	if err := loadedCfg.LoadMerchandisingRules("../rules.json"); err == nil {
+		t.Error("LoadMerchandisingRules did not return error for path traversal")
+	}
+}
+
+func TestConfigRejectsInvalidMerchandisingRules(t *testing.T) {
+
+	invalid := []config.MerchandisingRuleConfig{
+		{Type: "hide", ProductID: 1},
+		{Type: "pin", ProductID: 1, Position: 0},
+		{Type: "boost", Positions: 2},
+		{Type: "bury"},
+	}
+
+	for _, rule := range invalid {
+		cfg := config.NewConfig()
+		cfg.MerchandisingRules = []config.MerchandisingRuleConfig{rule}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate did not return error for %+v", rule)
+		}
+
+		cfg = config.NewConfig()
+		cfg.Tenants = map[string]config.TenantConfig{"outlet": {MerchandisingRules: []config.MerchandisingRuleConfig{rule}}}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate did not return error for tenant rule %+v", rule)
+		}
+	}
+
+	configFile := "temp_invalid_rules_config_test.json"
+	rulesFile := "temp_invalid_rules_test.json"
+	defer os.Remove(configFile)
+	defer os.Remove(rulesFile)
+
+	cfg := config.NewConfig()
+	cfg.MerchandisingRules = []config.MerchandisingRuleConfig{{Type: "pin", ProductID: 1}}
+	if err := cfg.SaveToFile(configFile); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+	if err := config.NewConfig().LoadFromFile(configFile); err == nil {
+		t.Error("LoadFromFile did not return error for a pin rule without a position")
+	}
+
+	if err := os.WriteFile(rulesFile, []byte(`[{"type": "boost", "positions": 0, "zero_views": true}]`), 0o600); err != nil {
+		t.Fatalf("Failed to write rules file: %v", err)
+	}
+	if err := config.NewConfig().LoadMerchandisingRules(rulesFile); err == nil {
+		t.Error("LoadMerchandisingRules did not return error for a boost rule without positions")
+	}
+}
+
+func TestConfigForTenant(t *testing.T) {
+
+	tempFile := "temp_tenant_config_test.json"
+	defer os.Remove(tempFile)
+
+	cfg := config.NewConfig()
+	cfg.DisabledSorters = []string{"name:desc"}
+	cfg.DefaultSorter = "price:asc"
+	cfg.MerchandisingRules = []config.MerchandisingRuleConfig{{Type: "bury", ZeroViews: true}}
+	cfg.Tenants = map[string]config.TenantConfig{
+		"outlet":   {DisabledSorters: []string{}, DefaultSorter: "price:desc"},
+		"flagship": {DefaultPageSize: 24, MerchandisingRules: []config.MerchandisingRuleConfig{}},
+	}
+
+	if err := cfg.SaveToFile(tempFile); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	loadedCfg := config.NewConfig()
+	if err := loadedCfg.LoadFromFile(tempFile); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	outlet := loadedCfg.ForTenant("outlet")
+	if outlet.DisabledSorters == nil || len(outlet.DisabledSorters) != 0 {
+		t.Errorf("Outlet should re-enable all sorters, got %v", outlet.DisabledSorters)
+	}
+	if outlet.DefaultSorter != "price:desc" || len(outlet.AllMerchandisingRules()) != 1 {
+		t.Errorf("Outlet overrides mismatch: %+v", outlet)
+	}
+
+	flagship := loadedCfg.ForTenant("flagship")
+	if len(flagship.DisabledSorters) != 1 || flagship.DefaultSorter != "price:asc" {
+		t.Errorf("Flagship did not inherit the shared settings: %+v", flagship)
+	}
+	if flagship.DefaultPageSize != 24 || len(flagship.AllMerchandisingRules()) != 0 {
+		t.Errorf("Flagship overrides mismatch: %+v", flagship)
+	}
+
+	unknown := loadedCfg.ForTenant("unknown")
+	if unknown.DefaultSorter != "price:asc" || unknown.Tenants != nil {
+		t.Errorf("Unknown tenant config mismatch: %+v", unknown)
+	}
+}
@@ -0,0 +1,212 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"assessment/infrastructure/config"
+)
+
+// writeConfigFile writes content to filename and moves its modification time
+// forward, so that the change is seen even on filesystems with coarse
+// timestamps.
+func writeConfigFile(t *testing.T, filename string, content string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", filename, err)
+	}
+	if err := os.Chtimes(filename, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set the modification time of %s: %v", filename, err)
+	}
+}
+
+func TestManagerReload(t *testing.T) {
+
+	configFile := "temp_manager_reload_test.json"
+	defer os.Remove(configFile)
+
+	start := time.Now()
+	writeConfigFile(t, configFile, `{"disabled_sorters": [], "default_page_size": 10}`, start)
+
+	manager, err := config.NewManager(configFile)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	var notified []*config.Config
+	unsubscribe := manager.Subscribe(func(cfg *config.Config) {
+		notified = append(notified, cfg)
+	})
+
+	writeConfigFile(t, configFile, `{"disabled_sorters": ["trending"], "default_page_size": 10}`, start.Add(time.Second))
+	if err := manager.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if !slices.Equal(manager.Current().DisabledSorters, []string{"trending"}) {
+		t.Errorf("Reloaded DisabledSorters mismatch: got %v", manager.Current().DisabledSorters)
+	}
+	if len(notified) != 1 || notified[0] != manager.Current() {
+		t.Fatalf("Subscriber not notified of the reloaded config: got %d notifications", len(notified))
+	}
+
+	good := manager.Current()
+	for _, invalid := range []string{
+		`{"disabled_sorters": [], "default_page_size": 0}`,
+		`{"sorters": [{"name": "No Type"}]}`,
+		`{"merchandising_rules": [{"type": "hide"}]}`,
+		`{"merchandising_rules": [{"type": "pin", "product_id": 1, "position": 0}]}`,
+		`{"disabled_sorters": [`,
+	} {
+		writeConfigFile(t, configFile, invalid, start.Add(2*time.Second))
+
+		if err := manager.Reload(); err == nil {
+			t.Errorf("Reload did not return error for %s", invalid)
+		}
+		if manager.Current() != good {
+			t.Errorf("Invalid config %s replaced the last good one", invalid)
+		}
+	}
+
+	if len(notified) != 1 {
+		t.Errorf("Subscriber notified of an invalid config: got %d notifications", len(notified))
+	}
+
+	unsubscribe()
+	writeConfigFile(t, configFile, `{"default_page_size": 20}`, start.Add(3*time.Second))
+	if err := manager.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if len(notified) != 1 {
+		t.Error("Subscriber notified after unsubscribing")
+	}
+}
+
+func TestManagerValidators(t *testing.T) {
+
+	configFile := "temp_manager_validators_test.json"
+	defer os.Remove(configFile)
+
+	writeConfigFile(t, configFile, `{"default_page_size": 10, "default_sorter": "missing"}`, time.Now())
+
+	rejectMissing := func(cfg *config.Config) error {
+		if cfg.DefaultSorter == "missing" {
+			return errors.New("default sorter not found")
+		}
+		return nil
+	}
+
+	_, err := config.NewManager(configFile, rejectMissing)
+	if err == nil || !strings.Contains(err.Error(), "default sorter not found") {
+		t.Errorf("NewManager did not apply the validator, got %v", err)
+	}
+
+	if _, err := config.NewManager("non-existent-config.json"); err == nil {
+		t.Error("NewManager did not return error for a non-existent file")
+	}
+}
+
+func TestManagerWatch(t *testing.T) {
+
+	configFile := "temp_manager_watch_test.json"
+	rulesFile := "temp_manager_watch_rules_test.json"
+	defer os.Remove(configFile)
+	defer os.Remove(rulesFile)
+
+	start := time.Now()
+	writeConfigFile(t, rulesFile, `[]`, start)
+	writeConfigFile(t, configFile, `{"default_page_size": 10, "merchandising_rules_file": "`+rulesFile+`"}`, start)
+
+	manager, err := config.NewManager(configFile)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	reloaded := make(chan *config.Config, 10)
+	manager.Subscribe(func(cfg *config.Config) { reloaded <- cfg })
+
+	var (
+		mutex    sync.Mutex
+		reported []error
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go manager.Watch(ctx, 5*time.Millisecond, func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		reported = append(reported, err)
+	})
+
+	waitForReload := func() *config.Config {
+		t.Helper()
+		select {
+		case cfg := <-reloaded:
+			return cfg
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for the config to be reloaded")
+			return nil
+		}
+	}
+
+	writeConfigFile(t, rulesFile, `[{"type": "bury", "zero_views": true}]`, start.Add(time.Second))
+	if cfg := waitForReload(); len(cfg.AllMerchandisingRules()) != 1 {
+		t.Errorf("Changed merchandising rules file not reloaded: got %v", cfg.AllMerchandisingRules())
+	}
+
+	writeConfigFile(t, configFile, `{"default_page_size": -1}`, start.Add(2*time.Second))
+	time.Sleep(50 * time.Millisecond)
+
+	mutex.Lock()
+	if len(reported) != 1 {
+		t.Errorf("Invalid config should be reported once, got %d errors", len(reported))
+	}
+	mutex.Unlock()
+
+	if manager.Current().DefaultPageSize != 10 {
+		t.Errorf("Invalid config became active: page size %d", manager.Current().DefaultPageSize)
+	}
+
+	writeConfigFile(t, configFile, `{"default_page_size": 25}`, start.Add(3*time.Second))
+	if cfg := waitForReload(); cfg.DefaultPageSize != 25 {
+		t.Errorf("Fixed config not reloaded: page size %d", cfg.DefaultPageSize)
+	}
+}
+
+func TestManagerWatchRankingModelFile(t *testing.T) {
+
+	configFile := "temp_manager_watch_model_config_test.json"
+	modelFile := "temp_manager_watch_model_test.json"
+	defer os.Remove(configFile)
+	defer os.Remove(modelFile)
+
+	start := time.Now()
+	writeConfigFile(t, modelFile, `{"features": [{"name": "price", "weight": 1}]}`, start)
+	writeConfigFile(t, configFile, `{"default_page_size": 10, "ranking_models": [{"name": "Learned Ranking", "model_file": "`+modelFile+`"}]}`, start)
+
+	manager, err := config.NewManager(configFile)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	reloaded := make(chan *config.Config, 10)
+	manager.Subscribe(func(cfg *config.Config) { reloaded <- cfg })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go manager.Watch(ctx, 5*time.Millisecond, nil)
+
+	writeConfigFile(t, modelFile, `{"features": [{"name": "price", "weight": -1}]}`, start.Add(time.Second))
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("Changed ranking model file did not reload the config")
+	}
+}
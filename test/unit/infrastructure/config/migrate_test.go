@@ -0,0 +1,150 @@
+package config_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	legacy "assessment/config"
+	"assessment/infrastructure/config"
+)
+
+func TestDetectVersion(t *testing.T) {
+
+	tests := []struct {
+		data string
+		want int
+	}{
+		{data: `{"sorters": {"price": {"enabled": true}}}`, want: config.VersionLegacy},
+		{data: `{"disabled_sorters": [], "default_page_size": 10}`, want: config.Version1},
+		{data: `{"sorters": [{"type": "field"}]}`, want: config.Version1},
+		{data: `{"version": 2, "disabled_sorters": []}`, want: config.Version2},
+	}
+
+	for _, tt := range tests {
+		got, err := config.DetectVersion([]byte(tt.data))
+		if err != nil {
+			t.Errorf("DetectVersion(%s) failed: %v", tt.data, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("DetectVersion(%s) = %d, want %d", tt.data, got, tt.want)
+		}
+	}
+
+	if _, err := config.DetectVersion([]byte(`{"version": "two"}`)); err == nil {
+		t.Error("DetectVersion did not return error for a non-numeric version")
+	}
+}
+
+func TestConfigLoadLegacyFormat(t *testing.T) {
+
+	legacyFile := "temp_legacy_config_test.json"
+	defer os.Remove(legacyFile)
+
+	legacyConfig := legacy.NewConfig()
+	legacyConfig.SetSorterConfig("price", legacy.SorterConfig{Name: "Price", Enabled: true, Ascending: false})
+	legacyConfig.SetSorterConfig("name", legacy.SorterConfig{Name: "Name", Enabled: false})
+	if err := legacyConfig.SaveToFile(legacyFile); err != nil {
+		t.Fatalf("SaveToFile failed for the legacy config: %v", err)
+	}
+
+	cfg := config.NewConfig()
+	if err := cfg.LoadFromFile(legacyFile); err != nil {
+		t.Fatalf("LoadFromFile failed for a legacy config: %v", err)
+	}
+
+	if cfg.Version != config.CurrentVersion {
+		t.Errorf("Loaded version mismatch: got %d, want %d", cfg.Version, config.CurrentVersion)
+	}
+	if !slices.Equal(cfg.DisabledSorters, []string{"name:asc", "name:desc"}) {
+		t.Errorf("Migrated DisabledSorters mismatch: got %v", cfg.DisabledSorters)
+	}
+	if !slices.Equal(cfg.SorterOrder, []string{"price:desc", "sales_per_view:desc", "created:asc"}) {
+		t.Errorf("Migrated SorterOrder mismatch: got %v", cfg.SorterOrder)
+	}
+
+	migrated := legacyConfig.Migrate()
+	if !slices.Equal(migrated.DisabledSorters, cfg.DisabledSorters) || !slices.Equal(migrated.SorterOrder, cfg.SorterOrder) {
+		t.Errorf("Migrate and LoadFromFile disagree: got %+v and %+v", migrated, cfg)
+	}
+}
+
+func TestConfigLoadUnsupportedVersion(t *testing.T) {
+
+	configFile := "temp_future_config_test.json"
+	defer os.Remove(configFile)
+
+	if err := os.WriteFile(configFile, []byte(`{"version": 99}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if err := config.NewConfig().LoadFromFile(configFile); err == nil {
+		t.Error("LoadFromFile did not return error for an unsupported version")
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+
+	configFile := "temp_migrate_config_test.json"
+	defer os.Remove(configFile)
+
+	if err := os.WriteFile(configFile, []byte(`{"sorters": {"price": {"enabled": true, "ascending": true}}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	version, err := config.MigrateFile(configFile)
+	if err != nil {
+		t.Fatalf("MigrateFile failed: %v", err)
+	}
+	if version != config.VersionLegacy {
+		t.Errorf("Source version mismatch: got %d, want %d", version, config.VersionLegacy)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("Failed to read migrated config: %v", err)
+	}
+	if migratedVersion, _ := config.DetectVersion(data); migratedVersion != config.CurrentVersion {
+		t.Errorf("Migrated file version mismatch: got %d, want %d", migratedVersion, config.CurrentVersion)
+	}
+
+	cfg := config.NewConfig()
+	if err := cfg.LoadFromFile(configFile); err != nil {
+		t.Fatalf("LoadFromFile failed for the migrated config: %v", err)
+	}
+	if len(cfg.DisabledSorters) != 6 || !slices.Equal(cfg.SorterOrder, []string{"price:asc"}) {
+		t.Errorf("Migrated settings mismatch: disabled %v, order %v", cfg.DisabledSorters, cfg.SorterOrder)
+	}
+
+	if version, err := config.MigrateFile(configFile); err != nil || version != config.CurrentVersion {
+		t.Errorf("MigrateFile on a current config = %d, %v", version, err)
+	}
+
+	if _, err := config.MigrateFile("../config.json"); err == nil {
+		t.Error("MigrateFile did not return error for path traversal")
+	}
+}
+
+func TestMigrateFileRejectsInvalidConfig(t *testing.T) {
+
+	configFile := "temp_migrate_invalid_config_test.json"
+	defer os.Remove(configFile)
+
+	original := `{"default_page_size": 0}`
+	if err := os.WriteFile(configFile, []byte(original), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if _, err := config.MigrateFile(configFile); err == nil {
+		t.Fatal("MigrateFile did not return error for an invalid config")
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if string(data) != original {
+		t.Errorf("MigrateFile rewrote an invalid config: got %s", data)
+	}
+}
@@ -0,0 +1,56 @@
+package services_test
+
+import (
+	"slices"
+	"testing"
+
+	"assessment/adapter/registry"
+	legacy "assessment/config"
+	"assessment/services"
+)
+
+func TestProductServiceGetEnabledSorters(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	productService := services.NewProductService(reg)
+	productService.InitializeDefaultSorters()
+
+	cfg := productService.GetConfig()
+	cfg.DisabledSorters = []string{"name:asc", "Name (descending)"}
+
+	enabled := productService.GetEnabledSorters()
+	slices.Sort(enabled)
+
+	want := []string{"created:asc", "created:desc", "price:asc", "price:desc", "sales_per_view:asc", "sales_per_view:desc"}
+	if !slices.Equal(enabled, want) {
+		t.Errorf("Enabled sorters mismatch: got %v, want %v", enabled, want)
+	}
+
+	sorted, err := productService.SortProducts(nil, "created:desc")
+	if err != nil || len(sorted) != 0 {
+		t.Errorf("SortProducts failed for sorter ID: %v", err)
+	}
+}
+
+func TestProductServiceWithMigratedLegacyConfig(t *testing.T) {
+
+	legacyConfig := legacy.NewConfig()
+	legacyConfig.SetSorterConfig("creation_date", legacy.SorterConfig{Name: "Creation Date", Enabled: true, Ascending: false})
+	legacyConfig.SetSorterConfig("sales_per_view", legacy.SorterConfig{Name: "Sales per View", Enabled: false})
+
+	reg := registry.NewSorterRegistry()
+
+	productService := services.NewProductService(reg)
+	productService.SetConfig(legacyConfig.Migrate())
+	productService.InitializeDefaultSorters()
+
+	want := []string{"price:asc", "created:desc", "name:asc", "price:desc", "created:asc", "name:desc"}
+	if got := productService.GetEnabledSorters(); !slices.Equal(got, want) {
+		t.Errorf("Enabled sorters mismatch: got %v, want %v", got, want)
+	}
+
+	if _, exists := reg.GetSorter("sales_per_view:desc"); exists {
+		t.Error("Sorters of a disabled legacy field were registered")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
 	"assessment/domain/model"
 	"assessment/infrastructure/config"
 	"assessment/usecase"
@@ -220,3 +221,37 @@ func TestPaginationWithDisabledSorter(t *testing.T) {
 		t.Error("SortAndPaginateProducts did not return error for disabled sorter")
 	}
 }
+
+func TestSortAndPaginateProductsStablePages(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	sorter.InitializeDefaultSorters(reg, nil)
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+
+	date, _ := time.Parse("2006-01-02", "2020-01-01")
+	products := make(model.ProductList, 0, 9)
+	for _, id := range []int{7, 3, 9, 1, 5, 2, 8, 4, 6} {
+		products = append(products, &model.Product{ID: id, Name: "Same", Price: 5.0, Created: date})
+	}
+
+	seen := make(map[int]bool)
+	for page := 1; page <= 3; page++ {
+		result, err := sorterUseCase.SortAndPaginateProducts(products, "Price (ascending)",
+			usecase.PaginationOptions{Page: page, PageSize: 4})
+		if err != nil {
+			t.Fatalf("SortAndPaginateProducts failed: %v", err)
+		}
+
+		for _, p := range result.Items {
+			if seen[p.ID] {
+				t.Errorf("Product %d appeared on more than one page", p.ID)
+			}
+			seen[p.ID] = true
+		}
+	}
+
+	if len(seen) != len(products) {
+		t.Errorf("Pages skipped products: saw %d of %d", len(seen), len(products))
+	}
+}
@@ -0,0 +1,172 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/model"
+	"assessment/usecase"
+)
+
+type CountingTopKSorter struct {
+	*sorter.PriceSorter
+	fullSorts int
+	topKSorts []int
+}
+
+func (s *CountingTopKSorter) Sort(products model.ProductList) model.ProductList {
+	s.fullSorts++
+	return s.PriceSorter.Sort(products)
+}
+
+func (s *CountingTopKSorter) SortContext(ctx context.Context, products model.ProductList) (model.ProductList, error) {
+	s.fullSorts++
+	return s.PriceSorter.SortContext(ctx, products)
+}
+
+func (s *CountingTopKSorter) SortTopK(products model.ProductList, k int) model.ProductList {
+	s.topKSorts = append(s.topKSorts, k)
+	return s.PriceSorter.SortTopK(products, k)
+}
+
+func createLargeCatalog(n int) model.ProductList {
+	products := make(model.ProductList, n)
+	for i := range products {
+		products[i] = &model.Product{
+			ID:         i + 1,
+			Name:       fmt.Sprintf("Product %d", i+1),
+			Price:      float64((i * 7919) % 1000),
+			SalesCount: (i * 31) % 500,
+			ViewsCount: (i*17)%5000 + 1,
+		}
+	}
+	return products
+}
+
+// createShuffledCatalog returns createLargeCatalog(n) in a fixed random order,
+// as catalogs rarely arrive sorted by ID.
+func createShuffledCatalog(n int) model.ProductList {
+	products := createLargeCatalog(n)
+	rand.New(rand.NewSource(1)).Shuffle(len(products), func(i, j int) {
+		products[i], products[j] = products[j], products[i]
+	})
+	return products
+}
+
+func TestSortAndPaginateProductsUsesTopK(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	counting := &CountingTopKSorter{PriceSorter: sorter.NewPriceSorter(true)}
+	reg.RegisterSorter(counting)
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+
+	products := createLargeCatalog(100)
+
+	result, err := sorterUseCase.SortAndPaginateProducts(products, "Price (ascending)",
+		usecase.PaginationOptions{Page: 2, PageSize: 10})
+	if err != nil {
+		t.Fatalf("SortAndPaginateProducts failed: %v", err)
+	}
+
+	if counting.fullSorts != 0 || len(counting.topKSorts) != 1 || counting.topKSorts[0] != 20 {
+		t.Fatalf("Expected a single top-20 sort, got %d full sorts and top-K sorts %v",
+			counting.fullSorts, counting.topKSorts)
+	}
+
+	full := sorter.NewPriceSorter(true).Sort(products)
+	for i, p := range result.Items {
+		if p.ID != full[10+i].ID {
+			t.Errorf("Top-K page differs from full sort at %d: got %d, want %d", i, p.ID, full[10+i].ID)
+		}
+	}
+
+	if result.TotalItems != 100 || result.TotalPages != 10 || !result.HasNext || !result.HasPrev {
+		t.Errorf("Pagination metadata mismatch: %+v", result)
+	}
+
+	_, err = sorterUseCase.SortAndPaginateProducts(products, "Price (ascending)",
+		usecase.PaginationOptions{Page: 10, PageSize: 10})
+	if err != nil {
+		t.Fatalf("SortAndPaginateProducts failed: %v", err)
+	}
+
+	if counting.fullSorts != 1 {
+		t.Errorf("Expected the last page to use a full sort, got %d full sorts", counting.fullSorts)
+	}
+}
+
+func BenchmarkSortAndPaginateProductsFirstPage(b *testing.B) {
+
+	reg := registry.NewSorterRegistry()
+	sorter.InitializeDefaultSorters(reg, nil)
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+
+	products := createLargeCatalog(1000000)
+	options := usecase.PaginationOptions{Page: 1, PageSize: 10}
+
+	b.Run("TopK", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := sorterUseCase.SortAndPaginateProducts(products, "Price (ascending)", options); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("FullSort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := sorterUseCase.SortProducts(products, "Price (ascending)"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	shuffled := createShuffledCatalog(1000000)
+
+	b.Run("TopKShuffled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := sorterUseCase.SortAndPaginateProducts(shuffled, "Price (ascending)", options); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestSortAndPaginateProductsJitterUsesSessionSeed(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	jitter, err := sorter.NewJitterSorter(sorter.NewSalesPerViewSorter(false), 5, 1)
+	if err != nil {
+		t.Fatalf("NewJitterSorter failed: %v", err)
+	}
+	reg.RegisterSorter(jitter)
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+
+	products := createLargeCatalog(100)
+	ctx := sorter.WithShuffleSeed(context.Background(), 12345)
+
+	sorted, err := sorterUseCase.SortProductsContext(ctx, products, jitter.ID())
+	if err != nil {
+		t.Fatalf("SortProductsContext failed: %v", err)
+	}
+
+	result, err := sorterUseCase.SortAndPaginateProductsContext(ctx, products, jitter.ID(),
+		usecase.PaginationOptions{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("SortAndPaginateProductsContext failed: %v", err)
+	}
+
+	for i, p := range result.Items {
+		if p.ID != sorted[i].ID {
+			t.Fatalf("Page order differs from the full order at %d: got %d, want %d", i, p.ID, sorted[i].ID)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/domain/model"
+	"assessment/usecase"
+)
+
+var errModelUnavailable = errors.New("model unavailable")
+
+type FailingSorter struct{}
+
+func (s *FailingSorter) Sort(products model.ProductList) model.ProductList {
+	return products.Clone()
+}
+
+func (s *FailingSorter) SortContext(_ context.Context, _ model.ProductList) (model.ProductList, error) {
+	return nil, errModelUnavailable
+}
+
+func (s *FailingSorter) Name() string {
+	return "Failing"
+}
+
+func TestProductSorterUseCaseSortProductsContext(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(NewMockSorter("MockSorter"))
+	reg.RegisterSorter(&FailingSorter{})
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+
+	products := createTestProducts()
+
+	sorted, err := sorterUseCase.SortProductsContext(context.Background(), products, "MockSorter")
+	if err != nil {
+		t.Fatalf("SortProductsContext failed: %v", err)
+	}
+	if len(sorted) != 3 {
+		t.Errorf("Product count mismatch: got %d, want %d", len(sorted), 3)
+	}
+
+	_, err = sorterUseCase.SortProductsContext(context.Background(), products, "Failing")
+	if !errors.Is(err, errModelUnavailable) {
+		t.Errorf("Sorter error was not surfaced: got %v", err)
+	}
+
+	_, err = sorterUseCase.SortProducts(products, "Failing")
+	if !errors.Is(err, errModelUnavailable) {
+		t.Errorf("Sorter error was not surfaced by SortProducts: got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	_, err = sorterUseCase.SortProductsContext(ctx, products, "MockSorter")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expired deadline was not honored: got %v", err)
+	}
+}
+
+func TestProductSorterUseCaseSortAndPaginateProductsContext(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(NewMockSorter("MockSorter"))
+	reg.RegisterSorter(&FailingSorter{})
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+
+	products := createTestProducts()
+	options := usecase.PaginationOptions{Page: 1, PageSize: 2}
+
+	result, err := sorterUseCase.SortAndPaginateProductsContext(context.Background(), products, "MockSorter", options)
+	if err != nil {
+		t.Fatalf("SortAndPaginateProductsContext failed: %v", err)
+	}
+	if len(result.Items) != 2 {
+		t.Errorf("Paginated items count mismatch: got %d, want %d", len(result.Items), 2)
+	}
+
+	_, err = sorterUseCase.SortAndPaginateProductsContext(context.Background(), products, "Failing", options)
+	if !errors.Is(err, errModelUnavailable) {
+		t.Errorf("Sorter error was not surfaced: got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = sorterUseCase.SortAndPaginateProductsContext(ctx, products, "MockSorter", options)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Cancellation was not honored: got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/infrastructure/config"
+	"assessment/usecase"
+)
+
+func TestProductSorterUseCaseExplain(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(sorter.NewPriceSorter(true))
+
+	cfg := config.NewConfig()
+	cfg.MerchandisingRules = []config.MerchandisingRuleConfig{{Type: "pin", ProductID: 3, Position: 1}}
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+	sorterUseCase.SetConfig(cfg)
+	sorterUseCase.SetClock(func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) })
+
+	explanations, err := sorterUseCase.Explain(createTestProducts(), "Price (ascending)")
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	wantIDs := []int{3, 1, 2}
+	wantKeys := []float64{30, 10, 20}
+	for i, e := range explanations {
+		if e.Position != i+1 || e.Product.ID != wantIDs[i] || e.Key != wantKeys[i] {
+			t.Errorf("Explanation %d mismatch: got position %d, ID %d, key %v", i, e.Position, e.Product.ID, e.Key)
+		}
+	}
+
+	if len(explanations[0].Adjustments) != 1 || explanations[0].Adjustments[0] != "pinned at position 1" {
+		t.Errorf("Merchandising adjustment not explained: %v", explanations[0].Adjustments)
+	}
+
+	if _, err := sorterUseCase.Explain(createTestProducts(), "Color"); err == nil {
+		t.Error("Explain did not return error for unknown sorter")
+	}
+}
+
+func TestProductSorterUseCaseExplainWithoutExplainer(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(NewMockSorter("MockSorter"))
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+
+	explanations, err := sorterUseCase.Explain(createTestProducts(), "MockSorter")
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	if len(explanations) != 3 {
+		t.Fatalf("Explanation count mismatch: got %d, want %d", len(explanations), 3)
+	}
+
+	for i, e := range explanations {
+		if e.Position != i+1 || e.Key != nil {
+			t.Errorf("Unexpected explanation from a sorter without Explain: %+v", e)
+		}
+	}
+}
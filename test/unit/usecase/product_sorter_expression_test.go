@@ -0,0 +1,79 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/domain/service"
+	"assessment/infrastructure/config"
+	"assessment/usecase"
+)
+
+func TestProductSorterUseCaseSortExpression(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+	sorterUseCase.SetExpressionParser(sorter.NewExpressionParser())
+
+	products := createTestProducts()
+
+	sortedProducts, err := sorterUseCase.SortProducts(products, "price:desc,name")
+	if err != nil {
+		t.Fatalf("SortProducts failed for sort expression: %v", err)
+	}
+
+	if sortedProducts[0].ID != 3 || sortedProducts[1].ID != 2 || sortedProducts[2].ID != 1 {
+		t.Error("Products not sorted correctly by sort expression")
+	}
+
+	_, err = sorterUseCase.SortProducts(products, "price:desc,color")
+	if err == nil {
+		t.Fatal("SortProducts did not return error for invalid sort expression")
+	}
+
+	var exprErr *sorter.ExpressionError
+	if !errors.As(err, &exprErr) || exprErr.Token != "color" {
+		t.Errorf("Error does not point to the bad token: %v", err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.DisabledSorters = []string{"Price (descending), then Name (ascending)"}
+	sorterUseCase.SetConfig(cfg)
+
+	_, err = sorterUseCase.SortProducts(products, "price:desc,name")
+	if err == nil {
+		t.Error("SortProducts did not return error for disabled sort expression")
+	}
+}
+
+func TestProductSorterUseCaseRegisteredSorterTakesPrecedence(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(&namedSorter{Sorter: sorter.NewPriceSorter(false), name: "price"})
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+	sorterUseCase.SetExpressionParser(sorter.NewExpressionParser())
+
+	products := createTestProducts()
+
+	sortedProducts, err := sorterUseCase.SortProducts(products, "price")
+	if err != nil {
+		t.Fatalf("SortProducts failed: %v", err)
+	}
+
+	if sortedProducts[0].ID != 3 {
+		t.Error("Sort expression was used instead of the registered sorter")
+	}
+}
+
+type namedSorter struct {
+	service.Sorter
+	name string
+}
+
+func (s *namedSorter) Name() string {
+	return s.name
+}
@@ -0,0 +1,69 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/infrastructure/config"
+	"assessment/usecase"
+)
+
+func TestProductSorterUseCaseMerchandising(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(sorter.NewPriceSorter(true))
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	campaignEnd := now.AddDate(0, 0, 7)
+
+	cfg := config.NewConfig()
+	cfg.MerchandisingRules = []config.MerchandisingRuleConfig{
+		{Type: "pin", ProductID: 3, Position: 1, End: &campaignEnd},
+	}
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+	sorterUseCase.SetConfig(cfg)
+	sorterUseCase.SetClock(func() time.Time { return now })
+
+	products := createTestProducts()
+
+	sortedProducts, err := sorterUseCase.SortProducts(products, "Price (ascending)")
+	if err != nil {
+		t.Fatalf("SortProducts failed: %v", err)
+	}
+
+	if sortedProducts[0].ID != 3 || sortedProducts[1].ID != 1 || sortedProducts[2].ID != 2 {
+		t.Error("Pinned product not placed first")
+	}
+
+	result, err := sorterUseCase.SortAndPaginateProducts(products, "Price (ascending)", usecase.PaginationOptions{Page: 1, PageSize: 1})
+	if err != nil {
+		t.Fatalf("SortAndPaginateProducts failed: %v", err)
+	}
+
+	if len(result.Items) != 1 || result.Items[0].ID != 3 {
+		t.Error("Merchandising rules not applied before pagination")
+	}
+
+	sorterUseCase.SetClock(func() time.Time { return campaignEnd })
+
+	sortedProducts, err = sorterUseCase.SortProducts(products, "Price (ascending)")
+	if err != nil {
+		t.Fatalf("SortProducts failed: %v", err)
+	}
+
+	if sortedProducts[0].ID != 1 {
+		t.Error("Expired merchandising rule was applied")
+	}
+
+	invalid := config.NewConfig()
+	invalid.MerchandisingRules = []config.MerchandisingRuleConfig{{Type: "boost", Positions: 1}}
+	sorterUseCase.SetConfig(invalid)
+
+	_, err = sorterUseCase.SortProducts(products, "Price (ascending)")
+	if err == nil {
+		t.Error("SortProducts did not return error for invalid merchandising rule")
+	}
+}
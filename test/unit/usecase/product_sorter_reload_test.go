@@ -0,0 +1,78 @@
+package usecase_test
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/infrastructure/config"
+	"assessment/usecase"
+)
+
+func TestProductSorterUseCaseReloadedConfig(t *testing.T) {
+
+	configFile := "temp_use_case_reload_test.json"
+	defer os.Remove(configFile)
+
+	if err := os.WriteFile(configFile, []byte(`{"disabled_sorters": [], "default_page_size": 10}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	manager, err := config.NewManager(configFile)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	reg := registry.NewSorterRegistry()
+	if err := sorter.InitializeDefaultSorters(reg, manager.Current()); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+	sorterUseCase.SetConfig(manager.Current())
+	manager.Subscribe(sorterUseCase.SetConfig)
+
+	products := createTestProducts()
+	if _, err := sorterUseCase.SortProducts(products, "trending"); err != nil {
+		t.Fatalf("SortProducts failed before the reload: %v", err)
+	}
+
+	// Sort concurrently with the reload to catch unsynchronized config access.
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					sorterUseCase.SortProducts(products, "price:asc")
+					sorterUseCase.GetAvailableSorters()
+				}
+			}
+		}()
+	}
+
+	later := time.Now().Add(time.Second)
+	if err := os.WriteFile(configFile, []byte(`{"disabled_sorters": ["trending"], "default_page_size": 10}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	os.Chtimes(configFile, later, later)
+
+	if err := manager.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	close(stop)
+	wg.Wait()
+
+	if _, err := sorterUseCase.SortProducts(products, "trending"); err == nil {
+		t.Error("Sorter disabled by the reloaded config is still usable")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
 	"assessment/domain/model"
 	"assessment/infrastructure/config"
 	"assessment/usecase"
@@ -216,3 +217,32 @@ func TestProductSorterUseCaseGetRegistry(t *testing.T) {
 		t.Error("Retrieved registry is not the same as the original")
 	}
 }
+
+func TestProductSorterUseCaseDisabledByID(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+	reg.RegisterSorter(sorter.NewPriceSorter(true))
+	reg.RegisterSorter(sorter.NewPriceSorter(false))
+
+	cfg := config.NewConfig()
+	cfg.DisabledSorters = []string{"price:desc"}
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+	sorterUseCase.SetConfig(cfg)
+
+	products := createTestProducts()
+
+	if _, err := sorterUseCase.SortProducts(products, "Price (descending)"); err == nil {
+		t.Error("SortProducts did not return error for sorter disabled by ID")
+	}
+
+	sorters := sorterUseCase.GetAvailableSorters()
+	if len(sorters) != 1 || sorters[0] != "price:asc" {
+		t.Errorf("Available sorters mismatch: got %v, want %v", sorters, []string{"price:asc"})
+	}
+
+	label, err := sorterUseCase.GetSorterLabel("price:asc", "fr")
+	if err != nil || label != "Prix (croissant)" {
+		t.Errorf("Sorter label mismatch: got %q, %v", label, err)
+	}
+}
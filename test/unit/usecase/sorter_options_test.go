@@ -0,0 +1,58 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/infrastructure/config"
+	"assessment/usecase"
+)
+
+func TestProductSorterUseCaseGetSorterOptions(t *testing.T) {
+
+	reg := registry.NewSorterRegistry()
+
+	cfg := config.NewConfig()
+	cfg.DisabledSorters = []string{"name:desc"}
+	cfg.DefaultSorter = "Creation Date (descending)"
+	cfg.SorterOrder = []string{"created:desc"}
+
+	if err := sorter.InitializeDefaultSorters(reg, cfg); err != nil {
+		t.Fatalf("InitializeDefaultSorters failed: %v", err)
+	}
+
+	sorterUseCase := usecase.NewProductSorterUseCase(reg)
+	sorterUseCase.SetConfig(cfg)
+
+	options := sorterUseCase.GetSorterOptions("de")
+
+	first := options[0]
+	if first.ID != "created:desc" || !first.Default {
+		t.Errorf("Configured default sorter not listed first: %+v", first)
+	}
+	if first.Label != "Erstellungsdatum (absteigend)" || first.Description != "Newest products first" {
+		t.Errorf("Option label or description mismatch: %+v", first)
+	}
+	if first.Category != sorter.CategoryDate {
+		t.Errorf("Option category mismatch: got %s, want %s", first.Category, sorter.CategoryDate)
+	}
+
+	if options[1].ID != "price:asc" || options[1].Default {
+		t.Errorf("Sorters not listed by priority after the configured order: %+v", options[1])
+	}
+
+	for _, option := range options {
+		if option.ID == "name:desc" {
+			t.Error("Disabled sorter listed as an option")
+		}
+	}
+
+	sorted, err := sorterUseCase.SortProducts(createTestProducts(), "")
+	if err != nil {
+		t.Fatalf("SortProducts failed for the default sorter: %v", err)
+	}
+	if len(sorted) != 3 {
+		t.Errorf("Product count mismatch: got %d, want %d", len(sorted), 3)
+	}
+}
@@ -0,0 +1,140 @@
+package usecase_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"assessment/adapter/registry"
+	"assessment/adapter/sorter"
+	"assessment/infrastructure/config"
+	"assessment/usecase"
+)
+
+func createTenantConfig() *config.Config {
+
+	cfg := config.NewConfig()
+	cfg.DisabledSorters = []string{"name:desc"}
+	cfg.DefaultSorter = "price:asc"
+	cfg.MerchandisingRules = []config.MerchandisingRuleConfig{{Type: "pin", ProductID: 3, Position: 1}}
+	cfg.Tenants = map[string]config.TenantConfig{
+		"outlet": {
+			DisabledSorters:    []string{},
+			DefaultSorter:      "priciest_first",
+			SorterOrder:        []string{"priciest_first"},
+			MerchandisingRules: []config.MerchandisingRuleConfig{},
+			Sorters: []config.SorterConfig{
+				{Type: "field", ID: "priciest_first", Name: "Priciest First", Params: json.RawMessage(`{"field": "price"}`)},
+			},
+		},
+	}
+
+	return cfg
+}
+
+func TestTenantProductSorterUseCase(t *testing.T) {
+
+	registries := registry.NewTenantSorterRegistry()
+	cfg := createTenantConfig()
+
+	if err := sorter.InitializeTenantSorters(registries, cfg, sorter.DefaultSorterFactories()); err != nil {
+		t.Fatalf("InitializeTenantSorters failed: %v", err)
+	}
+
+	tenantUseCase := usecase.NewTenantProductSorterUseCase(registries)
+	tenantUseCase.SetConfig(cfg)
+
+	products := createTestProducts()
+
+	tests := []struct {
+		tenant string
+		want   []int
+	}{
+		{tenant: "", want: []int{3, 1, 2}},
+		{tenant: "flagship", want: []int{3, 1, 2}},
+		{tenant: "outlet", want: []int{3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		sorted, err := tenantUseCase.SortProducts(tt.tenant, products, "")
+		if err != nil {
+			t.Errorf("SortProducts failed for tenant %q: %v", tt.tenant, err)
+			continue
+		}
+
+		for i, id := range tt.want {
+			if sorted[i].ID != id {
+				t.Errorf("Tenant %q default sort mismatch at %d: got %d, want %d", tt.tenant, i, sorted[i].ID, id)
+			}
+		}
+	}
+
+	sorted, err := tenantUseCase.SortProducts("outlet", products, "price:asc")
+	if err != nil {
+		t.Fatalf("SortProducts failed for the outlet: %v", err)
+	}
+	if sorted[0].ID != 1 {
+		t.Errorf("Outlet inherited the global merchandising rules: got %d first", sorted[0].ID)
+	}
+
+	if _, err := tenantUseCase.SortProducts("flagship", products, "priciest_first"); err == nil {
+		t.Error("Flagship could use a sorter registered for the outlet")
+	}
+
+	if _, err := tenantUseCase.SortProducts("flagship", products, "name:desc"); err == nil {
+		t.Error("Flagship could use a globally disabled sorter")
+	}
+	if _, err := tenantUseCase.SortProducts("outlet", products, "name:desc"); err != nil {
+		t.Errorf("Outlet could not use a sorter it re-enabled: %v", err)
+	}
+}
+
+func TestTenantProductSorterUseCaseBuildsTenantConfigsOnce(t *testing.T) {
+
+	cfg := createTenantConfig()
+	tenantUseCase := usecase.NewTenantProductSorterUseCase(registry.NewTenantSorterRegistry())
+	tenantUseCase.SetConfig(cfg)
+
+	for _, tenant := range []string{"", "flagship", "outlet"} {
+		first, second := tenantUseCase.ForTenant(tenant).GetConfig(), tenantUseCase.ForTenant(tenant).GetConfig()
+		if first != second {
+			t.Errorf("Tenant %q config was rebuilt between calls", tenant)
+		}
+	}
+
+	if tenantUseCase.ForTenant("outlet").GetConfig().DefaultSorter != "priciest_first" {
+		t.Error("Outlet config does not apply its overrides")
+	}
+
+	previous := tenantUseCase.ForTenant("outlet").GetConfig()
+	tenantUseCase.SetConfig(createTenantConfig())
+	if tenantUseCase.ForTenant("outlet").GetConfig() == previous {
+		t.Error("SetConfig did not rebuild the outlet config")
+	}
+}
+
+func TestTenantProductSorterUseCaseGetSorterOptions(t *testing.T) {
+
+	registries := registry.NewTenantSorterRegistry()
+	cfg := createTenantConfig()
+
+	if err := sorter.InitializeTenantSorters(registries, cfg, sorter.DefaultSorterFactories()); err != nil {
+		t.Fatalf("InitializeTenantSorters failed: %v", err)
+	}
+
+	tenantUseCase := usecase.NewTenantProductSorterUseCase(registries)
+	tenantUseCase.SetConfig(cfg)
+
+	outlet := tenantUseCase.GetSorterOptions("outlet", "en")
+	if outlet[0].ID != "priciest_first" || !outlet[0].Default {
+		t.Errorf("Outlet default sorter not listed first: %+v", outlet[0])
+	}
+
+	global := tenantUseCase.GetSorterOptions("", "en")
+	if global[0].ID != "price:asc" || !global[0].Default {
+		t.Errorf("Global default sorter not listed first: %+v", global[0])
+	}
+
+	if len(tenantUseCase.GetAvailableSorters("outlet")) != len(tenantUseCase.GetAvailableSorters(""))+2 {
+		t.Error("Outlet should list its own sorter and the re-enabled name:desc on top of the global ones")
+	}
+}
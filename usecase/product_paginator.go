@@ -1,8 +1,12 @@
 package usecase
 
 import (
-	"assessment/domain/model"
+	"context"
+	"fmt"
 	"math"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
 )
 
 type PaginationOptions struct {
@@ -25,18 +29,67 @@ func (ps *ProductSorterUseCase) SortAndPaginateProducts(
 	sorterName string,
 	options PaginationOptions,
 ) (*PaginatedResult, error) {
+	return ps.SortAndPaginateProductsContext(context.Background(), products, sorterName, options)
+}
+
+func (ps *ProductSorterUseCase) SortAndPaginateProductsContext(
+	ctx context.Context,
+	products model.ProductList,
+	sorterName string,
+	options PaginationOptions,
+) (*PaginatedResult, error) {
 
-	sortedProducts, err := ps.SortProducts(products, sorterName)
+	sorter, err := ps.resolveSorter(sorterName)
 	if err != nil {
 		return nil, err
 	}
 
-	return paginate(sortedProducts, options), nil
+	options = normalizePaginationOptions(options, len(products))
+
+	// Only the products up to the end of the requested page are needed, so
+	// sorters that support it skip sorting the rest of the catalog.
+	if topK, ok := partialSorter(sorter); ok {
+		k := options.Page * options.PageSize
+		if k < len(products) {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("sorter %s failed: %w", sorterName, err)
+			}
+			top := topK.SortTopK(products, k)
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("sorter %s failed: %w", sorterName, err)
+			}
+			return paginate(top, len(products), options), nil
+		}
+	}
+
+	sorted, err := service.AsSorterV2(sorter).SortContext(ctx, products)
+	if err != nil {
+		return nil, fmt.Errorf("sorter %s failed: %w", sorterName, err)
+	}
+
+	return paginate(sorted, len(products), options), nil
 }
 
-func paginate(products model.ProductList, options PaginationOptions) *PaginatedResult {
-	totalItems := len(products)
+// partialSorter returns sorter as a TopKSorter only when every wrapper down to
+// the sorter it wraps implements partial sorting itself. A wrapper without
+// SortTopK, such as a jitter sorter, would otherwise be bypassed or fall back
+// to Sort, skipping SortContext and the errors and session seed it honors.
+func partialSorter(sorter service.Sorter) (service.TopKSorter, bool) {
+	for layer := sorter; ; {
+		if _, ok := layer.(service.TopKSorter); !ok {
+			return nil, false
+		}
+		wrapper, ok := layer.(service.SorterWrapper)
+		if !ok {
+			break
+		}
+		layer = wrapper.Unwrap()
+	}
+
+	return sorter.(service.TopKSorter), true
+}
 
+func normalizePaginationOptions(options PaginationOptions, totalItems int) PaginationOptions {
 	if options.Page < 1 {
 		options.Page = 1
 	}
@@ -51,15 +104,23 @@ func paginate(products model.ProductList, options PaginationOptions) *PaginatedR
 		options.Page = totalPages
 	}
 
+	return options
+}
+
+func paginate(products model.ProductList, totalItems int, options PaginationOptions) *PaginatedResult {
+	options = normalizePaginationOptions(options, totalItems)
+
+	totalPages := int(math.Ceil(float64(totalItems) / float64(options.PageSize)))
+
 	startIndex := (options.Page - 1) * options.PageSize
 	endIndex := startIndex + options.PageSize
 
-	if endIndex > totalItems {
-		endIndex = totalItems
+	if endIndex > len(products) {
+		endIndex = len(products)
 	}
 
 	var pageItems model.ProductList
-	if startIndex < totalItems {
+	if startIndex < endIndex {
 		pageItems = products[startIndex:endIndex]
 	} else {
 		pageItems = model.ProductList{}
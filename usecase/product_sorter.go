@@ -1,7 +1,10 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"assessment/domain/model"
 	"assessment/domain/service"
@@ -10,61 +13,194 @@ import (
 
 type ProductSorterUseCase struct {
 	registry service.SorterRegistry
-	config   *config.Config
+	config   atomic.Pointer[activeConfig]
+	parser   service.SortExpressionParser
+	now      func() time.Time
+}
+
+// activeConfig is a config with its merchandising rules converted and
+// validated once, when it was set.
+type activeConfig struct {
+	config *config.Config
+	rules  []service.MerchandisingRule
+	err    error
+}
+
+func newActiveConfig(cfg *config.Config) *activeConfig {
+	active := &activeConfig{config: cfg}
+	if cfg == nil {
+		return active
+	}
+
+	for i, c := range cfg.AllMerchandisingRules() {
+		rule := c.Rule()
+		if err := rule.Validate(); err != nil {
+			active.err = fmt.Errorf("invalid merchandising rule %d: %w", i+1, err)
+			return active
+		}
+		active.rules = append(active.rules, rule)
+	}
+	return active
 }
 
 func NewProductSorterUseCase(registry service.SorterRegistry) *ProductSorterUseCase {
-	return &ProductSorterUseCase{
+	ps := &ProductSorterUseCase{
 		registry: registry,
-		config:   config.NewConfig(),
+		now:      time.Now,
 	}
+	ps.SetConfig(config.NewConfig())
+	return ps
 }
 
+// SetConfig atomically replaces the config, so that it can be reloaded while
+// products are being sorted. Each call uses the config that was current when
+// it started. cfg must not be modified afterwards.
 func (ps *ProductSorterUseCase) SetConfig(cfg *config.Config) {
-	ps.config = cfg
+	ps.config.Store(newActiveConfig(cfg))
 }
 
 func (ps *ProductSorterUseCase) GetConfig() *config.Config {
-	return ps.config
+	return ps.config.Load().config
 }
 
 func (ps *ProductSorterUseCase) GetRegistry() service.SorterRegistry {
 	return ps.registry
 }
 
+// SetExpressionParser enables ad-hoc sort expressions for names that do not
+// match a registered sorter.
+func (ps *ProductSorterUseCase) SetExpressionParser(parser service.SortExpressionParser) {
+	ps.parser = parser
+}
+
+// SetClock sets the time used to decide which merchandising rules are active.
+func (ps *ProductSorterUseCase) SetClock(now func() time.Time) {
+	ps.now = now
+}
+
 func (ps *ProductSorterUseCase) SortProducts(products model.ProductList, sorterName string) (model.ProductList, error) {
+	return ps.SortProductsContext(context.Background(), products, sorterName)
+}
+
+// SortProductsContext is SortProducts honoring the deadline and cancellation
+// of ctx, and surfacing errors reported by SorterV2 implementations.
+func (ps *ProductSorterUseCase) SortProductsContext(
+	ctx context.Context,
+	products model.ProductList,
+	sorterName string,
+) (model.ProductList, error) {
+	sorter, err := ps.resolveSorter(sorterName)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := service.AsSorterV2(sorter).SortContext(ctx, products)
+	if err != nil {
+		return nil, fmt.Errorf("sorter %s failed: %w", sorterName, err)
+	}
+
+	return sorted, nil
+}
+
+// Explain ranks products with the named sorter, including any merchandising
+// rules, and reports each product's position, sort key, tie-break and, for
+// composite and weighted sorters, the contribution of every component.
+func (ps *ProductSorterUseCase) Explain(products model.ProductList, sorterName string) ([]service.RankExplanation, error) {
+	sorter, err := ps.resolveSorter(sorterName)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.Explain(sorter, products), nil
+}
+
+func (ps *ProductSorterUseCase) resolveSorter(sorterName string) (service.Sorter, error) {
+	active := ps.config.Load()
+	cfg := active.config
+
+	if sorterName == "" {
+		sorterName = ps.defaultSorterID(cfg)
+	}
+
 	sorter, exists := ps.registry.GetSorter(sorterName)
 	if !exists {
-		return nil, fmt.Errorf("sorter not found: %s", sorterName)
+		if ps.parser == nil {
+			return nil, fmt.Errorf("sorter not found: %s", sorterName)
+		}
+
+		parsed, err := ps.parser.Parse(sorterName)
+		if err != nil {
+			return nil, fmt.Errorf("sorter not found: %s: %w", sorterName, err)
+		}
+		sorter = parsed
 	}
 
-	if !ps.isSorterEnabled(sorterName) {
+	if !ps.isSorterEnabled(cfg, sorterName) || !ps.isSorterEnabled(cfg, service.SorterID(sorter)) || !ps.isSorterEnabled(cfg, sorter.Name()) {
 		return nil, fmt.Errorf("sorter is disabled: %s", sorterName)
 	}
 
-	return sorter.Sort(products), nil
+	return ps.merchandise(active, sorter)
+}
+
+// merchandise wraps sorter with the configured merchandising rules, if any.
+// Invalid rules are normally rejected when the config is loaded; a config set
+// with them anyway fails every sort.
+func (ps *ProductSorterUseCase) merchandise(active *activeConfig, sorter service.Sorter) (service.Sorter, error) {
+	if active.err != nil {
+		return nil, active.err
+	}
+	if len(active.rules) == 0 {
+		return sorter, nil
+	}
+
+	return service.NewMerchandisedSorter(sorter, active.rules, ps.now), nil
 }
 
+// GetAvailableSorters returns the IDs of the enabled sorters.
 func (ps *ProductSorterUseCase) GetAvailableSorters() []string {
+	cfg := ps.GetConfig()
 	sorters := ps.registry.GetAllSorters()
-	names := make([]string, 0, len(sorters))
+	ids := make([]string, 0, len(sorters))
 
 	for _, sorter := range sorters {
-		if ps.isSorterEnabled(sorter.Name()) {
-			names = append(names, sorter.Name())
+		id := service.SorterID(sorter)
+		if ps.isSorterEnabled(cfg, id) {
+			ids = append(ids, id)
 		}
 	}
 
-	return names
+	return ids
 }
 
-func (ps *ProductSorterUseCase) isSorterEnabled(sorterName string) bool {
-	if ps.config == nil {
+// GetSorterLabel returns the display label of a sorter, looked up by ID or
+// alias, in the given locale.
+func (ps *ProductSorterUseCase) GetSorterLabel(sorterID string, locale string) (string, error) {
+	sorter, exists := ps.registry.GetSorter(sorterID)
+	if !exists {
+		return "", fmt.Errorf("sorter not found: %s", sorterID)
+	}
+
+	return service.SorterLabel(sorter, locale), nil
+}
+
+// isSorterEnabled reports whether the sorter with the given ID, name or
+// alias is missing from the disabled sorters, which may also be listed by any
+// of these.
+func (ps *ProductSorterUseCase) isSorterEnabled(cfg *config.Config, sorterName string) bool {
+	if cfg == nil {
 		return true
 	}
 
-	for _, disabled := range ps.config.DisabledSorters {
-		if disabled == sorterName {
+	id := sorterName
+	if sorter, exists := ps.registry.GetSorter(sorterName); exists {
+		id = service.SorterID(sorter)
+	}
+
+	for _, disabled := range cfg.DisabledSorters {
+		if disabled == sorterName || disabled == id {
+			return false
+		}
+		if sorter, exists := ps.registry.GetSorter(disabled); exists && service.SorterID(sorter) == id {
 			return false
 		}
 	}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"assessment/domain/service"
+	"assessment/infrastructure/config"
+)
+
+// SorterOption is an entry of a sort menu.
+type SorterOption struct {
+	service.SorterMetadata
+	Label string
+}
+
+// GetSorterOptions returns the enabled sorters in registry order with their
+// metadata and labels in the given locale, for rendering a sort menu. The
+// sorter configured as DefaultSorter, if any, is marked as the default.
+func (ps *ProductSorterUseCase) GetSorterOptions(locale string) []SorterOption {
+	cfg := ps.GetConfig()
+	defaultID := ps.defaultSorterID(cfg)
+
+	var options []SorterOption
+	for _, sorter := range ps.registry.GetAllSorters() {
+		metadata := service.DescribeSorter(sorter)
+		if !ps.isSorterEnabled(cfg, metadata.ID) {
+			continue
+		}
+
+		if defaultID != "" {
+			metadata.Default = metadata.ID == defaultID
+		}
+
+		options = append(options, SorterOption{
+			SorterMetadata: metadata,
+			Label:          service.SorterLabel(sorter, locale),
+		})
+	}
+
+	return options
+}
+
+// defaultSorterID resolves the configured default sorter to its ID.
+func (ps *ProductSorterUseCase) defaultSorterID(cfg *config.Config) string {
+	if cfg == nil || cfg.DefaultSorter == "" {
+		return ""
+	}
+
+	if sorter, exists := ps.registry.GetSorter(cfg.DefaultSorter); exists {
+		return service.SorterID(sorter)
+	}
+	return cfg.DefaultSorter
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"assessment/domain/model"
+	"assessment/domain/service"
+	"assessment/infrastructure/config"
+)
+
+// TenantProductSorterUseCase serves several storefronts from one process,
+// resolving sorters from each tenant's registry namespace and applying each
+// tenant's config overrides.
+type TenantProductSorterUseCase struct {
+	registries service.TenantSorterRegistry
+	config     atomic.Pointer[tenantConfigs]
+	parser     service.SortExpressionParser
+	now        func() time.Time
+}
+
+// tenantConfigs holds the active config of each tenant of a shared config,
+// built once when it was set. Tenants without overrides use shared.
+type tenantConfigs struct {
+	shared  *activeConfig
+	tenants map[string]*activeConfig
+}
+
+func NewTenantProductSorterUseCase(registries service.TenantSorterRegistry) *TenantProductSorterUseCase {
+	t := &TenantProductSorterUseCase{
+		registries: registries,
+		now:        time.Now,
+	}
+	t.SetConfig(config.NewConfig())
+	return t
+}
+
+// SetConfig atomically replaces the shared config, whose Tenants hold the
+// overrides of each tenant. cfg must not be modified afterwards.
+func (t *TenantProductSorterUseCase) SetConfig(cfg *config.Config) {
+	if cfg == nil {
+		cfg = config.NewConfig()
+	}
+
+	configs := &tenantConfigs{
+		shared:  newActiveConfig(cfg.ForTenant("")),
+		tenants: make(map[string]*activeConfig, len(cfg.Tenants)),
+	}
+	for tenantID := range cfg.Tenants {
+		configs.tenants[tenantID] = newActiveConfig(cfg.ForTenant(tenantID))
+	}
+	t.config.Store(configs)
+}
+
+func (t *TenantProductSorterUseCase) SetExpressionParser(parser service.SortExpressionParser) {
+	t.parser = parser
+}
+
+func (t *TenantProductSorterUseCase) SetClock(now func() time.Time) {
+	t.now = now
+}
+
+// ForTenant returns the use case of tenantID. An empty tenantID uses the
+// global registry and the shared config only.
+func (t *TenantProductSorterUseCase) ForTenant(tenantID string) *ProductSorterUseCase {
+	registry := t.registries.Global()
+	if tenantID != "" {
+		registry = t.registries.Tenant(tenantID)
+	}
+
+	configs := t.config.Load()
+	active, exists := configs.tenants[tenantID]
+	if !exists {
+		active = configs.shared
+	}
+
+	ps := &ProductSorterUseCase{
+		registry: registry,
+		parser:   t.parser,
+		now:      t.now,
+	}
+	ps.config.Store(active)
+	return ps
+}
+
+func (t *TenantProductSorterUseCase) SortProducts(tenantID string, products model.ProductList, sorterName string) (model.ProductList, error) {
+	return t.ForTenant(tenantID).SortProducts(products, sorterName)
+}
+
+func (t *TenantProductSorterUseCase) SortProductsContext(
+	ctx context.Context,
+	tenantID string,
+	products model.ProductList,
+	sorterName string,
+) (model.ProductList, error) {
+	return t.ForTenant(tenantID).SortProductsContext(ctx, products, sorterName)
+}
+
+func (t *TenantProductSorterUseCase) GetAvailableSorters(tenantID string) []string {
+	return t.ForTenant(tenantID).GetAvailableSorters()
+}
+
+func (t *TenantProductSorterUseCase) GetSorterOptions(tenantID string, locale string) []SorterOption {
+	return t.ForTenant(tenantID).GetSorterOptions(locale)
+}